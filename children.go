@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/mt1976/mwt-goToolkit/logs"
+)
+
+const childField = "Child"
+
+// childRelation is a one-to-many relationship, e.g. a Project has many ProjectActions via ProjectID
+type childRelation struct {
+	Name          string
+	Object        string
+	ObjectCamel   string
+	ForeignKey    string
+	ChildID       string
+	Columns       []childColumn
+	RangeChildren string
+	ChildIDValue  string
+	QueryValue    string
+}
+
+type childColumn struct {
+	Name  string
+	Value string
+}
+
+// addChildRelation adds a Child enrichment definition to the object.
+// The columns used are Field (the name of the relationship), LookupObject (the child object),
+// LookupKey (the field in the child holding the parent key), LookupValue (the child's key field)
+// and Columns (the child fields shown on the parent's view page, separated by |).
+func addChildRelation(record []string, en ObjectDefinition) ObjectDefinition {
+	c := childRelation{
+		Name:       strings.TrimSpace(record[enri_Field]),
+		Object:     strings.TrimSpace(record[enri_LookupObject]),
+		ForeignKey: strings.TrimSpace(record[enri_LookupKey]),
+		ChildID:    strings.TrimSpace(record[enri_LookupValue]),
+	}
+	if c.Name == "" || c.Object == "" || c.ForeignKey == "" {
+		logs.Warning("Child " + c.Name + " needs a Field, LookupObject & LookupKey, it has been ignored")
+		return en
+	}
+	if c.ChildID == "" {
+		c.ChildID = c.Object + "ID"
+		logs.Warning("Child " + c.Name + " has no LookupValue, using " + c.ChildID)
+	}
+	for _, col := range splitColumns(record[enri_ChildColumns]) {
+		c.Columns = append(c.Columns, childColumn{Name: col, Value: wrapVariable(col)})
+	}
+	if len(c.Columns) == 0 {
+		c.Columns = append(c.Columns, childColumn{Name: c.ChildID, Value: wrapVariable(c.ChildID)})
+	}
+	c.ObjectCamel = strings.ToLower(c.Object[:1]) + c.Object[1:]
+	c.RangeChildren = "{{range ." + c.Name + "_children}}"
+	c.ChildIDValue = wrapVariable(c.ChildID)
+	// The child's pages are opened by its own query string, which the page is given as <Name>_query
+	c.QueryValue = "{{$." + c.Name + "_query}}"
+
+	en.Children = append(en.Children, c)
+	en.HasChildren = true
+	return en
+}
+
+// splitColumns splits a list of field names separated by | or spaces
+func splitColumns(in string) []string {
+	return strings.FieldsFunc(in, func(r rune) bool {
+		return r == '|' || r == ' '
+	})
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_addChildRelation(t *testing.T) {
+	record := func(field, object, key, id, columns string) []string {
+		r := padEnrichment([]string{childField, field, object, key, id})
+		r[enri_ChildColumns] = columns
+		return r
+	}
+	tests := []struct {
+		name    string
+		record  []string
+		want    int
+		childID string
+		columns int
+	}{
+		{"Test 1", record("Actions", "ProjectAction", "ProjectID", "ProjectActionID", "Name|DueDate"), 1, "ProjectActionID", 2},
+		{"Test 2", record("Actions", "ProjectAction", "ProjectID", "", ""), 1, "ProjectActionID", 1},
+		{"Test 3", record("Actions", "ProjectAction", "", "", ""), 0, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := addChildRelation(tt.record, ObjectDefinition{ObjectName: "Project"})
+			if len(got.Children) != tt.want {
+				t.Fatalf("addChildRelation() children = %v, want %v", len(got.Children), tt.want)
+			}
+			if tt.want == 0 {
+				return
+			}
+			c := got.Children[0]
+			if c.ChildID != tt.childID || len(c.Columns) != tt.columns || c.RangeChildren != "{{range .Actions_children}}" || c.QueryValue != "{{$.Actions_query}}" {
+				t.Errorf("addChildRelation() = %v %v %v, want %v %v", c.ChildID, len(c.Columns), c.RangeChildren, tt.childID, tt.columns)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mt1976/mwt-goToolkit/logs"
+)
+
+// computedMacro describes a function that can be used in the expression of a Computed field.
+// The Go snippet is generated inline so the target application needs no extra helpers.
+type computedMacro struct {
+	Snippet string
+	Imports []string
+}
+
+// computedMacros are the functions that can be used in a Computed expression, %[1]s is the field
+var computedMacros = map[string]computedMacro{
+	"upper":     {Snippet: "strings.ToUpper(%[1]s)", Imports: []string{"strings"}},
+	"lower":     {Snippet: "strings.ToLower(%[1]s)", Imports: []string{"strings"}},
+	"trim":      {Snippet: "strings.TrimSpace(%[1]s)", Imports: []string{"strings"}},
+	"age":       {Snippet: computedDateSnippet("n := time.Now(); a := n.Year() - t.Year(); if n.YearDay() < t.YearDay() { a-- }; return strconv.Itoa(a)"), Imports: []string{"strconv", "time"}},
+	"daysuntil": {Snippet: computedDateSnippet("return strconv.Itoa(int(time.Until(t).Hours() / 24))"), Imports: []string{"strconv", "time"}},
+	"dayssince": {Snippet: computedDateSnippet("return strconv.Itoa(int(time.Since(t).Hours() / 24))"), Imports: []string{"strconv", "time"}},
+}
+
+var computedToken = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|[A-Za-z_][A-Za-z0-9_]*(?:\s*\(\s*[A-Za-z_][A-Za-z0-9_]*\s*\))?`)
+var computedCall = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)\s*\(\s*([A-Za-z_][A-Za-z0-9_]*)\s*\)$`)
+
+// computedDateSnippet wraps a calculation on a date field (yyyy-mm-dd...), t is the parsed date.
+func computedDateSnippet(calc string) string {
+	return "func() string { if len(%[1]s) < 10 { return \"\" }; t, err := time.Parse(core.DATEFORMATSIENA, %[1]s[:10]); if err != nil { return \"\" }; " + calc + " }()"
+}
+
+// translateExpression converts the expression of a Computed field into Go code.
+// Field names are replaced by the field on the record (r.FieldName) and known functions are expanded.
+// Anything else is passed through untouched, so the expression may also contain plain Go.
+func translateExpression(expression string, fields []FieldProperties) (string, []string) {
+	var imports []string
+	goCode := computedToken.ReplaceAllStringFunc(expression, func(token string) string {
+		if strings.HasPrefix(token, "\"") {
+			return token
+		}
+		if call := computedCall.FindStringSubmatch(token); call != nil {
+			macro, ok := computedMacros[strings.ToLower(call[1])]
+			if !ok {
+				return call[1] + "(" + computedReference(call[2], fields) + ")"
+			}
+			imports = append(imports, macro.Imports...)
+			return fmt.Sprintf(macro.Snippet, computedReference(call[2], fields))
+		}
+		return computedReference(token, fields)
+	})
+	return goCode, imports
+}
+
+// computedReference returns the record reference for a field name, or the name itself if it is not a field
+func computedReference(name string, fields []FieldProperties) string {
+	for _, f := range fields {
+		if f.FieldName == name || f.FieldSQL == name {
+			return "r." + f.FieldName
+		}
+	}
+	return name
+}
+
+// setupComputedFields translates the expressions of all Computed fields once the field list is complete,
+// as a Computed field may refer to fields defined after it.
+func setupComputedFields(en ObjectDefinition) ObjectDefinition {
+	var imports []string
+	for i, f := range en.FieldsList {
+		if !f.IsComputed {
+			continue
+		}
+		if f.Expression == "" {
+			logs.Warning("Computed Field " + f.FieldName + " has no expression")
+			continue
+		}
+		goCode, used := translateExpression(f.Expression, en.FieldsList)
+		en.FieldsList[i].ComputedGo = goCode
+		imports = append(imports, used...)
+		en.HasComputed = true
+	}
+	en.ComputedImports = uniqueStrings(imports)
+	return en
+}
+
+// uniqueStrings returns the sorted, distinct values of a list of strings
+func uniqueStrings(in []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, s := range in {
+		if s != "" && !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_translateExpression(t *testing.T) {
+	fields := []FieldProperties{{FieldName: "FirstName"}, {FieldName: "LastName"}, {FieldName: "SYSCreated", FieldSQL: "_created"}}
+	type args struct {
+		expression string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        string
+		wantImports []string
+	}{
+		{"Test 1", args{`FirstName + " " + LastName`}, `r.FirstName + " " + r.LastName`, nil},
+		{"Test 2", args{`upper(LastName)`}, `strings.ToUpper(r.LastName)`, []string{"strings"}},
+		{"Test 3", args{`"FirstName"`}, `"FirstName"`, nil},
+		{"Test 4", args{`strings.Title(FirstName)`}, `strings.Title(r.FirstName)`, nil},
+		{"Test 5", args{`_created`}, `r.SYSCreated`, nil},
+		{"Test 6", args{`Unknown`}, `Unknown`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotImports := translateExpression(tt.args.expression, fields)
+			if got != tt.want {
+				t.Errorf("translateExpression() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotImports, tt.wantImports) {
+				t.Errorf("translateExpression() imports = %v, want %v", gotImports, tt.wantImports)
+			}
+		})
+	}
+}
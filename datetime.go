@@ -0,0 +1,108 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	core "github.com/mt1976/mwt-goToolkit/core"
+	"github.com/mt1976/mwt-goToolkit/logs"
+)
+
+const (
+	timezoneDefault = "UTC"
+	dateTimeLocal   = core.DATEFORMATSIENA + "T" + core.TIMEHMS
+	// fetchTime is the dao helper used to read every date & time type
+	fetchTime = "Time"
+)
+
+// temporalType describes how a date/time field type is entered, shown & stored
+type temporalType struct {
+	FieldType string
+	Input     string
+	Display   string
+	Store     string
+	Step      string
+}
+
+var temporalTypes = map[string]temporalType{
+	"Date":      {"date", core.DATEFORMATSIENA, core.DATEFORMATUSER, core.DATEFORMATSIENA, ""},
+	"Time":      {"time", core.TIMEHMS, core.TIMEFORMATUSER, core.TIMEHMS, "1"},
+	"DateTime":  {"datetime-local", dateTimeLocal, core.DATETIMEFORMATUSER, core.DATETIMEFORMATSQLSERVER, "1"},
+	"Timestamp": {"datetime-local", dateTimeLocal, core.DATETIMEFORMATUSER, core.DFNANO + "Z07:00", "1"},
+}
+
+// temporalInputTypes promote a String field to a date/time type when its InputType is overridden
+var temporalInputTypes = map[string]string{
+	"date":           "Date",
+	"time":           "Time",
+	"datetime":       "DateTime",
+	"datetime-local": "DateTime",
+}
+
+// timeLayouts are the layouts the generated code recognises a date/time in, most specific first
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	core.DFNANO,
+	core.DATETIMEFORMATSQLSERVER,
+	dateTimeLocal,
+	core.DATEFORMATSIENA + "T15:04",
+	core.DATETIMEFORMATUSER,
+	core.DATEFORMATSIENA,
+	core.DATEFORMATUSER,
+	core.TIMEHMS,
+	"15:04",
+}
+
+// getTimezone returns the timezone property of the object, or the project (application.cfg), defaulting to UTC
+func getTimezone(props map[string]string) string {
+	tz := strings.TrimSpace(props["timezone"])
+	if tz == "" {
+		tz = strings.TrimSpace(core.Properties["timezone"])
+	}
+	if tz == "" {
+		return timezoneDefault
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		logs.Warning("Invalid timezone " + tz + ", using " + timezoneDefault)
+		return timezoneDefault
+	}
+	return tz
+}
+
+// setupTemporalFields prepares the Date, Time, DateTime & Timestamp fields. Values are entered in the HTML5 format,
+// stored in the database format and shown in the user format, all using the core format constants.
+// A default of now or today is set when a new record is created.
+func setupTemporalFields(e ObjectDefinition) ObjectDefinition {
+	for i, f := range e.FieldsList {
+		if tp, ok := temporalInputTypes[f.FieldType]; ok && f.Type == "String" {
+			f.Type = tp
+		}
+		tt, ok := temporalTypes[f.Type]
+		if !ok {
+			continue
+		}
+		f.IsTemporal = true
+		f.FetchType = fetchTime
+		f.FieldType = tt.FieldType
+		f.FieldMask = ""
+		f.NumericStep = tt.Step
+		f.InputLayout = tt.Input
+		f.DisplayLayout = tt.Display
+		f.StoreLayout = tt.Store
+		switch strings.ToLower(f.Default) {
+		case "now", "today":
+			f.TimeDefault = strings.ToLower(f.Default)
+			f.Default = ""
+		}
+		f.Formatted = wrapVariable(f.FieldName + "_fmt")
+		f.TemplateField = f.Formatted
+		e.HasTemporal = true
+		e.FieldsList[i] = f
+	}
+	if e.HasTemporal {
+		e.TimeLayouts = timeLayouts
+	}
+	e.HasDerived = e.HasDerived || e.HasTemporal
+	e.HasFormatted = e.HasMoney || e.HasTemporal
+	return e
+}
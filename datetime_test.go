@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+
+	core "github.com/mt1976/mwt-goToolkit/core"
+)
+
+func Test_setupTemporalFields(t *testing.T) {
+	e := ObjectDefinition{ObjectName: "Test", FieldsList: []FieldProperties{
+		{FieldName: "Due", Type: "Date", Default: "Today"},
+		{FieldName: "Start", Type: "String", FieldType: "datetime", FieldMask: "yyyy-mm-dd hh:mm"},
+		{FieldName: "At", Type: "Time"},
+		{FieldName: "Name", Type: "String"},
+	}}
+	e = setupTemporalFields(e)
+
+	if !e.HasTemporal || !e.HasDerived || !e.HasFormatted {
+		t.Errorf("setupTemporalFields() HasTemporal = %v, HasDerived = %v, HasFormatted = %v, want true", e.HasTemporal, e.HasDerived, e.HasFormatted)
+	}
+	tests := []struct {
+		name        string
+		isTemporal  bool
+		fieldType   string
+		store       string
+		timeDefault string
+	}{
+		{"Test 1", true, "date", core.DATEFORMATSIENA, "today"},
+		{"Test 2", true, "datetime-local", core.DATETIMEFORMATSQLSERVER, ""},
+		{"Test 3", true, "time", core.TIMEHMS, ""},
+		{"Test 4", false, "", "", ""},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := e.FieldsList[i]
+			if got.IsTemporal != tt.isTemporal || got.FieldType != tt.fieldType || got.StoreLayout != tt.store || got.TimeDefault != tt.timeDefault || got.FieldMask != "" {
+				t.Errorf("setupTemporalFields() %v = %v %v %v %v, want %v %v %v %v", got.FieldName, got.IsTemporal, got.FieldType, got.StoreLayout, got.TimeDefault, tt.isTemporal, tt.fieldType, tt.store, tt.timeDefault)
+			}
+			if got.IsTemporal && (got.Default != "" || got.FetchType != fetchTime) {
+				t.Errorf("setupTemporalFields() %v Default = %v, FetchType = %v", got.FieldName, got.Default, got.FetchType)
+			}
+		})
+	}
+}
+
+func Test_getTimezone(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"Test 1", "", timezoneDefault},
+		{"Test 2", "Europe/London", "Europe/London"},
+		{"Test 3", "Nowhere/Special", timezoneDefault},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getTimezone(map[string]string{"timezone": tt.in}); got != tt.want {
+				t.Errorf("getTimezone() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/mt1976/mwt-goToolkit/logs"
+)
+
+// exportFormat is a format the list of an object can be exported in
+type exportFormat struct {
+	Name  string
+	Label string
+	Glyph string
+}
+
+// exportFormats are the formats an export can be generated for, in the order the list page offers them
+var exportFormats = []exportFormat{
+	{"csv", "CSV", "fas fa-file-csv"},
+	{"xlsx", "Excel", "fas fa-file-excel"},
+	{"json", "JSON", "fas fa-file-code"},
+}
+
+// getExportFormats returns the exportformats property, a list of formats separated by commas, defaulting to every format
+func getExportFormats(props map[string]string) []exportFormat {
+	names := splitRoles(strings.ToLower(props["exportformats"]))
+	if len(names) == 0 {
+		return exportFormats
+	}
+	wanted := make(map[string]bool)
+	for _, name := range names {
+		wanted[name] = true
+	}
+	var formats []exportFormat
+	for _, f := range exportFormats {
+		if wanted[f.Name] {
+			formats = append(formats, f)
+			delete(wanted, f.Name)
+		}
+	}
+	for _, name := range names {
+		if wanted[name] {
+			logs.Warning("Unknown export format " + name + ", use csv, xlsx or json")
+			delete(wanted, name)
+		}
+	}
+	if len(formats) == 0 {
+		return exportFormats
+	}
+	return formats
+}
+
+// hasExportFormat returns true if the object exports its list in a format
+func hasExportFormat(e ObjectDefinition, name string) bool {
+	for _, f := range e.ExportFormats {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// exportsSpreadsheet returns true if any object's routes export an Excel file
+func exportsSpreadsheet(objects []ObjectDefinition) bool {
+	for _, o := range objects {
+		if o.CanExport && hasExportFormat(o, "xlsx") && hasArtifact([]ObjectDefinition{o}, "routes") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_getExportFormats(t *testing.T) {
+	tests := []struct {
+		name  string
+		props map[string]string
+		want  []string
+	}{
+		{"Test 1", map[string]string{}, []string{"csv", "xlsx", "json"}},
+		{"Test 2", map[string]string{"exportformats": "json, CSV"}, []string{"csv", "json"}},
+		{"Test 3", map[string]string{"exportformats": "xlsx,pdf"}, []string{"xlsx"}},
+		{"Test 4", map[string]string{"exportformats": "pdf"}, []string{"csv", "xlsx", "json"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, f := range getExportFormats(tt.props) {
+				got = append(got, f.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getExportFormats() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_hasExportFormat(t *testing.T) {
+	e := ObjectDefinition{ExportFormats: getExportFormats(map[string]string{"exportformats": "csv"})}
+	tests := []struct {
+		name   string
+		format string
+		want   bool
+	}{
+		{"Test 1", "csv", true},
+		{"Test 2", "xlsx", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasExportFormat(e, tt.format); got != tt.want {
+				t.Errorf("hasExportFormat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ require (
 	github.com/alexedwards/scs/v2 v2.5.0
 	github.com/denisenkom/go-mssqldb v0.11.0
 	github.com/google/uuid v1.3.0
+	github.com/hako/durafmt v0.0.0-20210608085754-5c1018a4e16b
 	github.com/jimlawless/cfg v0.0.0-20160326141742-136e0c264d31
 	github.com/leekchan/accounting v1.0.0
 	github.com/spf13/viper v1.15.0
@@ -17,7 +18,6 @@ require (
 	github.com/cockroachdb/apd v1.1.0 // indirect
 	github.com/fsnotify/fsnotify v1.6.0 // indirect
 	github.com/golang-sql/civil v0.0.0-20220223132316-b832511892a9 // indirect
-	github.com/hako/durafmt v0.0.0-20210608085754-5c1018a4e16b // indirect
 	github.com/hashicorp/hcl v1.0.0 // indirect
 	github.com/magiconair/properties v1.8.7 // indirect
 	github.com/mitchellh/mapstructure v1.5.0 // indirect
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"strings"
+
+	core "github.com/mt1976/mwt-goToolkit/core"
+)
+
+// graphQLObject is an object of the GraphQL schema, its queries & mutations follow the actions the object allows
+type graphQLObject struct {
+	Name         string
+	Camel        string
+	FriendlyName string
+	QueryFieldID string
+	Fields       []graphQLField
+	Inputs       []graphQLField
+	Lookups      []graphQLLookup
+	CanList      bool
+	CanView      bool
+	CanCreate    bool
+	CanUpdate    bool
+	CanDelete    bool
+	HasRoles     bool
+	Hidden       []string
+	Protected    []string
+}
+
+type graphQLField struct {
+	Name        string
+	Type        string
+	Description string
+}
+
+// graphQLLookup is a lookup field typed as the object it looks up
+type graphQLLookup struct {
+	Name        string
+	Field       string
+	Object      string
+	ObjectKey   string
+	ObjectCamel string
+	HasRoles    bool
+	Hidden      bool
+}
+
+// buildGraphQL describes the objects that create a GraphQL schema. Every value is held as a string, so every field is
+// a String; only the mandatory fields of an input are required. A lookup of an object in the schema adds a field typed
+// as that object, named after the lookup field without its ID suffix.
+func buildGraphQL(objects []ObjectDefinition) []graphQLObject {
+	known := make(map[string]ObjectDefinition)
+	for _, o := range objects {
+		if o.HasGraphQL {
+			known[o.ObjectName] = o
+		}
+	}
+	var schema []graphQLObject
+	for _, o := range objects {
+		if !o.HasGraphQL {
+			continue
+		}
+		g := graphQLObject{
+			Name:         o.ObjectName,
+			Camel:        o.ObjectCamelCase,
+			FriendlyName: o.FriendlyName,
+			QueryFieldID: o.QueryFieldID,
+			CanList:      o.CanList,
+			CanView:      o.CanView,
+			CanCreate:    o.CanNew && o.CanSave,
+			CanUpdate:    o.CanEdit && o.CanSave,
+			CanDelete:    o.CanDelete,
+			HasRoles:     o.HasRoles,
+		}
+		g.Hidden, g.Protected = securedFields(o)
+		names := make(map[string]bool)
+		for _, f := range o.FieldsList {
+			names[f.FieldName] = true
+		}
+		for _, f := range o.FieldsList {
+			var notes []string
+			if f.IsComputed {
+				notes = append(notes, "Computed : "+f.Expression)
+			}
+			if len(f.HiddenFrom) > 0 {
+				notes = append(notes, "Hidden from "+strings.Join(f.HiddenFrom, ", "))
+			}
+			field := graphQLField{Name: f.FieldName, Type: "String!"}
+			if len(notes) > 0 {
+				// A GraphQL string has the escapes of a JSON string
+				field.Description = jsonString(strings.Join(notes, ". "))
+			}
+			g.Fields = append(g.Fields, field)
+			if !f.IsComputed && !f.IsAudit {
+				input := graphQLField{Name: f.FieldName, Type: "String"}
+				if f.IsMandatory {
+					input.Type = "String!"
+				}
+				g.Inputs = append(g.Inputs, input)
+			}
+			if lo, ok := known[f.LookupObject]; f.IsLookup && ok {
+				name := strings.TrimSuffix(f.FieldName, "ID")
+				if name == f.FieldName || name == "" || names[name] {
+					name = f.FieldName + "Object"
+				}
+				names[name] = true
+				hidden, _ := securedFields(lo)
+				g.Lookups = append(g.Lookups, graphQLLookup{Name: name, Field: f.FieldName, Object: f.LookupObject, ObjectKey: lo.QueryFieldID,
+					ObjectCamel: lo.ObjectCamelCase, HasRoles: lo.HasRoles, Hidden: len(hidden) > 0})
+			}
+		}
+		schema = append(schema, g)
+	}
+	return schema
+}
+
+// hasGraphQLMutations returns true if any object of the schema has a mutation
+func hasGraphQLMutations(schema []graphQLObject) bool {
+	for _, g := range schema {
+		if g.CanCreate || g.CanUpdate || g.CanDelete {
+			return true
+		}
+	}
+	return false
+}
+
+// generateGraphQLArtifacts writes the GraphQL schema & the resolver stubs of the objects that create one
+func generateGraphQLArtifacts(p projectDefinition) {
+	if len(p.GraphQL) == 0 {
+		return
+	}
+	resolver := "resolver.go"
+	if core.Properties["deliverto"] == "" {
+		resolver = resolver + "_tmp"
+	}
+	processProjectArtifact("graphql", ".graphql_template", "graphql", "schema.graphql", p)
+	processProjectArtifact("resolver", go_template, "graphql", resolver, p)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_buildGraphQL(t *testing.T) {
+	objects := []ObjectDefinition{
+		{ObjectName: "Project", HasGraphQL: true, CanList: true, CanView: true, CanNew: true, CanSave: true, FieldsList: []FieldProperties{
+			{FieldName: "ProjectID", IsMandatory: true},
+			{FieldName: "StateID", IsLookup: true, LookupObject: "State"},
+			{FieldName: "OwnerID", IsLookup: true, LookupObject: "Owner"},
+			{FieldName: "Days", IsComputed: true, Expression: `upper("x")`},
+		}},
+		{ObjectName: "State", ObjectCamelCase: "state", QueryFieldID: "StateID", HasGraphQL: true, CanView: true, HasRoles: true, FieldsList: []FieldProperties{
+			{FieldName: "Cost", IsSecured: true, HiddenFrom: []string{"clerk"}},
+			{FieldName: "Code", IsSecured: true, ReadOnlyFor: []string{"clerk"}},
+		}},
+		{ObjectName: "Owner", QueryFieldID: "OwnerID"},
+	}
+	schema := buildGraphQL(objects)
+	if len(schema) != 2 {
+		t.Fatalf("buildGraphQL() = %v objects, want 2", len(schema))
+	}
+	p := schema[0]
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"Test 1", len(p.Lookups), 1},
+		{"Test 2", p.Lookups[0].Name + " " + p.Lookups[0].Object + " " + p.Lookups[0].ObjectKey, "State State StateID"},
+		{"Test 3", len(p.Inputs), 3},
+		{"Test 4", p.Inputs[0].Type + " " + p.Inputs[1].Type, "String! String"},
+		{"Test 5", p.Fields[3].Description, `"Computed : upper(\"x\")"`},
+		{"Test 6", p.CanCreate && !p.CanUpdate && !p.CanDelete, true},
+		{"Test 7", hasGraphQLMutations(schema[1:]), false},
+		{"Test 8", p.HasRoles || len(p.Hidden) > 0 || len(p.Protected) > 0, false},
+		{"Test 9", p.Lookups[0].ObjectCamel, "state"},
+		{"Test 10", p.Lookups[0].HasRoles && p.Lookups[0].Hidden, true},
+		{"Test 11", strings.Join(schema[1].Hidden, ",") + " " + strings.Join(schema[1].Protected, ","), "Cost Cost,Code"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("buildGraphQL() = %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
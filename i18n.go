@@ -0,0 +1,155 @@
+package main
+
+const translationFolder = "design/i18n"
+
+// translation is a text of the generated pages or messages, with the key the application translates it by
+type translation struct {
+	Key       string
+	Text      string
+	IsMessage bool
+}
+
+// pageText is a button or heading of the generated pages, shown if On is true
+type pageText struct {
+	Name string
+	Text string
+	On   bool
+}
+
+// wrapText returns the runtime lookup of a translated text, the pages are given the texts of their object as Text
+func wrapText(key string) string {
+	return "{{index $.Text " + enquote(key) + "}}"
+}
+
+// setupTranslations gives every label, placeholder, help text, button & field check message of an object a key of the form Object.Kind.Name.
+// The application translates a key with its English text as the default, the keys & texts are the object's catalogue.
+func setupTranslations(e ObjectDefinition) ObjectDefinition {
+	e.Translations = nil
+	e.PageText = make(map[string]string)
+	add := func(name string, text string) {
+		key := e.ObjectName + "." + name
+		if _, ok := e.PageText[name]; ok {
+			return
+		}
+		e.PageText[name] = wrapText(key)
+		e.Translations = append(e.Translations, translation{Key: key, Text: text})
+	}
+
+	for i, f := range e.FieldsList {
+		e.FieldsList[i].TextKey = e.ObjectName + ".Message." + f.FieldName
+		e.FieldsList[i].LabelText = wrapText(e.ObjectName + ".Field." + f.FieldName)
+		add("Field."+f.FieldName, f.Label)
+		if f.Placeholder != "" {
+			e.FieldsList[i].PlaceholderText = wrapText(e.ObjectName + ".Placeholder." + f.FieldName)
+			add("Placeholder."+f.FieldName, f.Placeholder)
+		}
+		if f.Help != "" {
+			e.FieldsList[i].HelpText = wrapText(e.ObjectName + ".Help." + f.FieldName)
+			add("Help."+f.FieldName, f.Help)
+		}
+	}
+	for i, j := range e.Junctions {
+		e.Junctions[i].LabelText = wrapText(e.ObjectName + ".Field." + j.Name)
+		add("Field."+j.Name, fieldLabel(j.Name))
+	}
+
+	for _, c := range e.Children {
+		add("Child."+c.Name, fieldLabel(c.Name))
+	}
+
+	hasTabs := false
+	for _, f := range e.FieldsList {
+		if f.Tab != "" {
+			add("Tab."+f.Tab, f.Tab)
+			hasTabs = true
+		}
+		if f.Section != "" {
+			add("Section."+f.Section, f.Section)
+		}
+	}
+	if hasTabs {
+		add("Tab."+layoutDefaultTab, layoutDefaultTab)
+	}
+
+	for _, t := range pageTexts(e) {
+		if t.On {
+			add(t.Name, t.Text)
+		}
+	}
+	if e.CanExport {
+		for _, f := range e.ExportFormats {
+			add("Export."+f.Name, f.Label)
+		}
+	}
+
+	for _, f := range e.FieldsList {
+		for _, m := range fieldMessages(f) {
+			e.Translations = append(e.Translations, translation{Key: f.TextKey + "." + m.Name, Text: m.Text, IsMessage: true})
+		}
+	}
+	return e
+}
+
+// pageTexts are the buttons & headings of the pages of an object
+func pageTexts(e ObjectDefinition) []pageText {
+	return []pageText{
+		{"Button.New", "New", e.CanNew},
+		{"Button.Import", "Import", e.CanImport},
+		{"Button.Edit", "Edit", e.CanEdit},
+		{"Button.Save", "Save", e.CanSave},
+		{"Button.Print", "Print", true},
+		{"Button.Cancel", "Cancel", true},
+		{"Button.Add", "Add", e.HasChildren},
+		{"Text.Search", "Search", e.HasListQuery},
+		{"Text.Actions", "Actions", e.CanList || e.HasChildren},
+		{"Text.DryRun", "Dry run, check the rows without storing them", e.CanImport},
+		{"Text.ImportColumns", "The first row names the columns, by field or database column. Other columns are ignored", e.CanImport},
+	}
+}
+
+// fieldMessages are the messages of the checks the dao makes of a field, named as the dao's keys name them
+func fieldMessages(f FieldProperties) []pageText {
+	var messages []pageText
+	if f.IsMoney {
+		messages = append(messages, pageText{Name: "Amount", Text: "Invalid amount"})
+	}
+	if f.IsTemporal {
+		messages = append(messages, pageText{Name: "DateTime", Text: "Invalid date/time"})
+	}
+	if f.IsRequired {
+		messages = append(messages, pageText{Name: "Mandatory", Text: "Mandatory"})
+	}
+	if f.Pattern != "" {
+		messages = append(messages, pageText{Name: "Format", Text: "Invalid format"})
+	}
+	if f.IsNumeric {
+		if f.Min != "" {
+			messages = append(messages, pageText{Name: "Minimum", Text: "Below the minimum of " + f.Min})
+		}
+		if f.Max != "" {
+			messages = append(messages, pageText{Name: "Maximum", Text: "Above the maximum of " + f.Max})
+		}
+	} else {
+		if f.Min != "" && f.Min != "0" {
+			messages = append(messages, pageText{Name: "MinLength", Text: "Shorter than " + f.Min + " characters"})
+		}
+		if f.Max != "" {
+			messages = append(messages, pageText{Name: "MaxLength", Text: "Longer than " + f.Max + " characters"})
+		}
+	}
+	return messages
+}
+
+// generateTranslationArtifact writes the catalogue of an object, the English text of each key
+func generateTranslationArtifact(e ObjectDefinition) ObjectDefinition {
+	catalogue := make(map[string]string)
+	for _, t := range e.Translations {
+		catalogue[t.Key] = t.Text
+	}
+	dest := "/" + translationFolder + "/" + e.ObjectCamelCase + ".json"
+	name := writeDesignJSON(dest, catalogue)
+	if name == "" {
+		return e
+	}
+	return logArtifact("i18n", dest, e, "design", name)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_setupTranslations(t *testing.T) {
+	e := ObjectDefinition{ObjectName: "Project", CanSave: true, FieldsList: []FieldProperties{
+		{FieldName: "Name", IsRequired: true, Max: "60", Tab: "Main"},
+		{FieldName: "DayRate", IsNumeric: true, Min: "0", Section: "Rates", Help: "Charged per day"},
+	}, Junctions: []junctionRelation{{Name: "States"}}}
+	e = setupTranslations(setupFieldLabels(e))
+	catalogue := make(map[string]string)
+	for _, tr := range e.Translations {
+		catalogue[tr.Key] = tr.Text
+	}
+	tests := []struct {
+		name string
+		key  string
+		want string
+	}{
+		{"Test 1", "Project.Field.Name", "Name"},
+		{"Test 2", "Project.Field.States", "States"},
+		{"Test 3", "Project.Tab.Main", "Main"},
+		{"Test 4", "Project.Tab.General", "General"},
+		{"Test 5", "Project.Section.Rates", "Rates"},
+		{"Test 6", "Project.Button.Save", "Save"},
+		{"Test 7", "Project.Button.New", ""},
+		{"Test 8", "Project.Message.Name.Mandatory", "Mandatory"},
+		{"Test 9", "Project.Message.Name.MaxLength", "Longer than 60 characters"},
+		{"Test 10", "Project.Message.DayRate.Minimum", "Below the minimum of 0"},
+		{"Test 11", "Project.Field.DayRate", "Day Rate"},
+		{"Test 12", "Project.Help.DayRate", "Charged per day"},
+		{"Test 13", "Project.Placeholder.DayRate", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := catalogue[tt.key]; got != tt.want {
+				t.Errorf("setupTranslations() %v = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+	if e.FieldsList[0].LabelText != `{{index $.Text "Project.Field.Name"}}` || e.PageText["Button.Save"] != `{{index $.Text "Project.Button.Save"}}` {
+		t.Errorf("setupTranslations() LabelText = %v, PageText = %v", e.FieldsList[0].LabelText, e.PageText["Button.Save"])
+	}
+}
+
+func Test_fieldMessages(t *testing.T) {
+	tests := []struct {
+		name string
+		f    FieldProperties
+		want []string
+	}{
+		{"Test 1", FieldProperties{}, nil},
+		{"Test 2", FieldProperties{IsMoney: true, Pattern: "^[0-9]+$"}, []string{"Amount", "Format"}},
+		{"Test 3", FieldProperties{IsTemporal: true, IsRequired: true}, []string{"DateTime", "Mandatory"}},
+		{"Test 4", FieldProperties{IsNumeric: true, Min: "1", Max: "9"}, []string{"Minimum", "Maximum"}},
+		{"Test 5", FieldProperties{Min: "0", Max: "9"}, []string{"MaxLength"}},
+		{"Test 6", FieldProperties{Min: "2"}, []string{"MinLength"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, m := range fieldMessages(tt.f) {
+				got = append(got, m.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fieldMessages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
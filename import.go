@@ -0,0 +1,58 @@
+package main
+
+import (
+	"github.com/mt1976/mwt-goToolkit/logs"
+)
+
+// importResultString is the report shown on the import page once a file has been uploaded, a line for each row of the file
+const importResultString = `{{if .HasResult}}<div class="card mt-3">
+            <div class="card-header h5">{{.Result.FileName}}{{if .Result.DryRun}} <span class="badge bg-info ms-2">Dry Run</span>{{end}}</div>
+            <div class="card-body">
+              {{if .Result.Error}}<div class="alert alert-danger">{{.Result.Error}}</div>{{else}}
+              <p>{{.Result.Valid}} valid, {{.Result.Invalid}} invalid, {{.Result.Stored}} stored</p>
+              <table class="table table-sm">
+                <thead class="table-primary text-uppercase"><tr><th>Column</th><th>Field</th></tr></thead>
+                <tbody>{{range .Result.Columns}}<tr{{if not .Field}} class="text-muted"{{end}}><td>{{.Column}}</td><td>{{if .Field}}{{.Field}}{{else}}Ignored{{end}}</td></tr>{{end}}</tbody>
+              </table>
+              <table class="table table-sm table-hover">
+                <thead class="table-primary text-uppercase"><tr><th>Row</th><th>ID</th><th>Status</th><th>Errors</th></tr></thead>
+                <tbody>{{range .Result.Rows}}<tr class="{{if .Errors}}table-danger{{else}}table-success{{end}}"><td>{{.Row}}</td><td>{{.ID}}</td><td>{{if .Errors}}Invalid{{else if .Stored}}Stored{{else}}Valid{{end}}</td><td>{{range .Errors}}<div>{{.}}</div>{{end}}</td></tr>{{end}}</tbody>
+              </table>{{end}}
+            </div>
+          </div>{{end}}`
+
+// setupImport works out which fields an imported file may set, the key & the fields stored in the database except
+// the audit & computed fields. An object without such fields cannot be imported.
+func setupImport(e ObjectDefinition) ObjectDefinition {
+	if !e.CanImport {
+		return e
+	}
+	count := 0
+	for i, f := range e.FieldsList {
+		e.FieldsList[i].IsImportable = !f.IsExtra && !f.IsAudit && !f.IsComputed
+		if e.FieldsList[i].IsImportable {
+			count++
+			if f.IsTemporal {
+				e.HasImportTime = true
+			}
+		}
+	}
+	if count == 0 {
+		logs.Warning(e.ObjectName + " has no fields that can be imported, it cannot be imported")
+		e.CanImport = false
+		return e
+	}
+	e.TemplateImportResult = importResultString
+	// The generated test imports a row breaking the first check of a field the file can set
+	importable := make(map[string]bool)
+	for _, f := range e.FieldsList {
+		importable[f.FieldName] = f.IsImportable
+	}
+	for _, c := range e.ValidationCases {
+		if !c.Valid && importable[c.Field] {
+			e.ImportCase = c
+			break
+		}
+	}
+	return e
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_setupImport(t *testing.T) {
+	e := ObjectDefinition{ObjectName: "Project", CanImport: true, FieldsList: []FieldProperties{
+		{FieldName: "ProjectID"},
+		{FieldName: "StartDate", IsTemporal: true},
+		{FieldName: "Sessions", IsExtra: true},
+		{FieldName: "Title", IsComputed: true},
+		{FieldName: "SYSCreated", IsAudit: true, IsTemporal: true},
+	}, ValidationCases: []validationCase{
+		{Name: "Valid record", Valid: true},
+		{Name: "Sessions is above 99", Field: "Sessions", Value: "100"},
+		{Name: "StartDate has an invalid format", Field: "StartDate", Value: invalidPattern},
+	}}
+	e = setupImport(e)
+	tests := []struct {
+		name string
+		i    int
+		want bool
+	}{
+		{"Test 1", 0, true},
+		{"Test 2", 1, true},
+		{"Test 3", 2, false},
+		{"Test 4", 3, false},
+		{"Test 5", 4, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := e.FieldsList[tt.i].IsImportable; got != tt.want {
+				t.Errorf("setupImport() %v IsImportable = %v, want %v", e.FieldsList[tt.i].FieldName, got, tt.want)
+			}
+		})
+	}
+	if !e.CanImport || !e.HasImportTime || e.TemplateImportResult == "" || e.ImportCase.Field != "StartDate" {
+		t.Errorf("setupImport() = %v %v %v", e.CanImport, e.HasImportTime, e.ImportCase)
+	}
+
+	none := setupImport(ObjectDefinition{ObjectName: "Empty", CanImport: true, FieldsList: []FieldProperties{{FieldName: "Title", IsComputed: true}}})
+	if none.CanImport {
+		t.Errorf("setupImport() without importable fields CanImport = true")
+	}
+}
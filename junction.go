@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mt1976/mwt-goToolkit/logs"
+)
+
+const junctionField = "Junction"
+
+// junctionRangeString lists the related items in a multi-select, the linked ones are selected
+const junctionRangeString = "{{range .%s_lookup}}<option value=\"%s\" {{if index $.%s_selected .ID}}selected{{end}} data-mdb-secondary-text=\"%s\">%s</option>{{end}}"
+
+// junctionRelation is a many-to-many relationship held in a junction table,
+// e.g. a Project has many Features, and a Feature belongs to many Projects, via ProjectFeature
+type junctionRelation struct {
+	Name        string
+	Object      string
+	ObjectCamel string
+	Table       string
+	Key         string
+	RelatedKey  string
+	RangeHTML   string
+	LabelText   string
+}
+
+// addJunctionRelation adds a Junction enrichment definition to the object.
+// The columns used are Field (the name of the relationship), LookupObject (the related object),
+// LookupKey (the junction column holding this object's key), LookupValue (the junction column holding the related key)
+// and Table (the junction table, defaulting to the two object names).
+// The related object must provide a lookup, which is used for the multi-select on the edit page.
+func addJunctionRelation(record []string, en ObjectDefinition) ObjectDefinition {
+	j := junctionRelation{
+		Name:       strings.TrimSpace(record[enri_Field]),
+		Object:     strings.TrimSpace(record[enri_LookupObject]),
+		Key:        strings.TrimSpace(record[enri_LookupKey]),
+		RelatedKey: strings.TrimSpace(record[enri_LookupValue]),
+		Table:      strings.TrimSpace(record[enri_Table]),
+	}
+	if j.Name == "" || j.Object == "" {
+		logs.Warning("Junction " + j.Name + " needs a Field & LookupObject, it has been ignored")
+		return en
+	}
+	if j.Key == "" {
+		j.Key = en.ObjectName + "ID"
+		logs.Warning("Junction " + j.Name + " has no LookupKey, using " + j.Key)
+	}
+	if j.RelatedKey == "" {
+		j.RelatedKey = j.Object + "ID"
+		logs.Warning("Junction " + j.Name + " has no LookupValue, using " + j.RelatedKey)
+	}
+	if strings.EqualFold(j.Key, j.RelatedKey) {
+		logs.Warning("Junction " + j.Name + " uses " + j.Key + " for both keys, it has been ignored")
+		return en
+	}
+	if j.Table == "" {
+		j.Table = en.ObjectName + j.Object
+	}
+	j.ObjectCamel = strings.ToLower(j.Object[:1]) + j.Object[1:]
+	j.RangeHTML = fmt.Sprintf(junctionRangeString, j.Name, wrapVariable("ID"), j.Name, wrapVariable("ID"), wrapVariable("Name"))
+
+	en.Junctions = append(en.Junctions, j)
+	en.HasJunctions = true
+	return en
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_addJunctionRelation(t *testing.T) {
+	record := func(field, object, key, relatedKey, table string) []string {
+		r := padEnrichment([]string{junctionField, field, object, key, relatedKey})
+		r[enri_Table] = table
+		return r
+	}
+	tests := []struct {
+		name       string
+		record     []string
+		want       int
+		key        string
+		relatedKey string
+		table      string
+	}{
+		{"Test 1", record("Features", "Feature", "ProjectID", "FeatureID", "ProjectFeatures"), 1, "ProjectID", "FeatureID", "ProjectFeatures"},
+		{"Test 2", record("Features", "Feature", "", "", ""), 1, "ProjectID", "FeatureID", "ProjectFeature"},
+		{"Test 3", record("Features", "", "ProjectID", "FeatureID", ""), 0, "", "", ""},
+		{"Test 4", record("Related", "Project", "ProjectID", "ProjectID", ""), 0, "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := addJunctionRelation(tt.record, ObjectDefinition{ObjectName: "Project"})
+			if len(got.Junctions) != tt.want || got.HasJunctions != (tt.want > 0) {
+				t.Fatalf("addJunctionRelation() junctions = %v, want %v", len(got.Junctions), tt.want)
+			}
+			if tt.want == 0 {
+				return
+			}
+			j := got.Junctions[0]
+			if j.Key != tt.key || j.RelatedKey != tt.relatedKey || j.Table != tt.table {
+				t.Errorf("addJunctionRelation() = %v %v %v, want %v %v %v", j.Key, j.RelatedKey, j.Table, tt.key, tt.relatedKey, tt.table)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+// labelOverrides applies the Label, Placeholder, Help & Description columns of an enrichment definition to a field
+func labelOverrides(record []string, field FieldProperties) FieldProperties {
+	if record[enri_Label] != "" {
+		field.Label = strings.TrimSpace(record[enri_Label])
+	}
+	if record[enri_Placeholder] != "" {
+		field.Placeholder = strings.TrimSpace(record[enri_Placeholder])
+	}
+	if record[enri_Help] != "" {
+		field.Help = strings.TrimSpace(record[enri_Help])
+	}
+	if record[enri_Description] != "" {
+		field.Description = strings.TrimSpace(record[enri_Description])
+	}
+	return field
+}
+
+// setupFieldLabels gives every field without a Label one made from its name
+func setupFieldLabels(e ObjectDefinition) ObjectDefinition {
+	for i, f := range e.FieldsList {
+		if f.Label == "" {
+			e.FieldsList[i].Label = fieldLabel(f.FieldName)
+		}
+	}
+	return e
+}
+
+// fieldLabel returns the words of a camel case field name, without an ID suffix, e.g. ProjectStateID is "Project State".
+// An acronym is kept as a word, so BudgetCCY is "Budget CCY".
+func fieldLabel(name string) string {
+	var words []string
+	for _, part := range strings.Split(name, "_") {
+		runes := []rune(part)
+		start := 0
+		for i := 1; i < len(runes); i++ {
+			lowerBefore := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			acronymEnd := unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsUpper(runes[i]) && (lowerBefore || acronymEnd) {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+		if start < len(runes) {
+			words = append(words, string(runes[start:]))
+		}
+	}
+	if len(words) > 1 && words[len(words)-1] == "ID" {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_fieldLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"Test 1", "ProjectStateID", "Project State"},
+		{"Test 2", "BudgetCCY", "Budget CCY"},
+		{"Test 3", "NoEstimationSessions", "No Estimation Sessions"},
+		{"Test 4", "SYSCreatedBy", "SYS Created By"},
+		{"Test 5", "ID", "ID"},
+		{"Test 6", "Name", "Name"},
+		{"Test 7", "HTTPServerID", "HTTP Server"},
+		{"Test 8", "Address2Line", "Address2 Line"},
+		{"Test 9", "project_stateID", "project state"},
+		{"Test 10", "SYSId", "SYS Id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldLabel(tt.in); got != tt.want {
+				t.Errorf("fieldLabel(%v) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_labelOverrides(t *testing.T) {
+	record := make([]string, enri_Columns)
+	record[enri_Label] = " Day Rate "
+	record[enri_Help] = "The rate charged per day"
+	f := labelOverrides(record, FieldProperties{FieldName: "ProjectRate", Placeholder: "0.00"})
+	if f.Label != "Day Rate" || f.Help != "The rate charged per day" || f.Placeholder != "0.00" || f.Description != "" {
+		t.Errorf("labelOverrides() = %q %q %q %q", f.Label, f.Placeholder, f.Help, f.Description)
+	}
+
+	e := setupFieldLabels(ObjectDefinition{FieldsList: []FieldProperties{f, {FieldName: "OriginID"}}})
+	if e.FieldsList[0].Label != "Day Rate" || e.FieldsList[1].Label != "Origin" {
+		t.Errorf("setupFieldLabels() = %q %q", e.FieldsList[0].Label, e.FieldsList[1].Label)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	core "github.com/mt1976/mwt-goToolkit/core"
+	"github.com/mt1976/mwt-goToolkit/logs"
+)
+
+const (
+	layoutTemplate   = "layout"
+	layoutDefaultTab = "General"
+)
+
+// layoutOverrides applies the Order, Section & Tab columns of an enrichment definition to a field
+func layoutOverrides(record []string, field FieldProperties) FieldProperties {
+	if record[enri_Order] != "" {
+		order, err := strconv.Atoi(strings.TrimSpace(record[enri_Order]))
+		if err != nil {
+			logs.Warning("Invalid Order " + record[enri_Order] + " for " + field.FieldName)
+		} else {
+			field.DisplayOrder = order
+		}
+	}
+	if record[enri_Section] != "" {
+		field.Section = strings.TrimSpace(record[enri_Section])
+	}
+	if record[enri_Tab] != "" {
+		field.Tab = strings.TrimSpace(record[enri_Tab])
+	}
+	return field
+}
+
+// setupFieldLayout orders the fields and groups them into tabs & sections for the HTML templates.
+// Fields without an explicit Order keep their position, numbered 10, 20, 30... so an Order of 15
+// places a field between the first and second fields. Only the pages are ordered, the field list
+// (and so the struct, the database columns & the API) keeps the order of the source.
+func setupFieldLayout(e ObjectDefinition) ObjectDefinition {
+	fields := append([]FieldProperties{}, e.FieldsList...)
+	for i := range fields {
+		if fields[i].DisplayOrder == 0 {
+			fields[i].DisplayOrder = (i + 1) * 10
+		}
+	}
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].DisplayOrder < fields[j].DisplayOrder
+	})
+
+	e.Tabs = nil
+	e.Sections = nil
+	for _, f := range fields {
+		t := findTab(e.Tabs, f.Tab)
+		if t < 0 {
+			e.Tabs = append(e.Tabs, fieldTab{Name: f.Tab, ID: layoutID(e.ObjectName, f.Tab)})
+			t = len(e.Tabs) - 1
+		}
+		s := findSection(e.Tabs[t].Sections, f.Section)
+		if s < 0 {
+			e.Tabs[t].Sections = append(e.Tabs[t].Sections, fieldSection{Name: f.Section, ID: layoutID(e.ObjectName, f.Tab+"-"+f.Section), Tab: f.Tab})
+			s = len(e.Tabs[t].Sections) - 1
+		}
+		e.Tabs[t].Sections[s].Fields = append(e.Tabs[t].Sections[s].Fields, f)
+		e.HasTabs = e.HasTabs || f.Tab != ""
+		e.HasSections = e.HasSections || f.Section != ""
+	}
+	for i, t := range e.Tabs {
+		if e.HasTabs && t.Name == "" {
+			// Fields without a tab are grouped on a "General" tab
+			e.Tabs[i].Name = layoutDefaultTab
+			e.Tabs[i].ID = layoutID(e.ObjectName, layoutDefaultTab)
+			for j := range e.Tabs[i].Sections {
+				e.Tabs[i].Sections[j].Tab = layoutDefaultTab
+			}
+		}
+		e.Sections = append(e.Sections, e.Tabs[i].Sections...)
+	}
+	return e
+}
+
+func findTab(tabs []fieldTab, name string) int {
+	for i, t := range tabs {
+		if strings.EqualFold(t.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func findSection(sections []fieldSection, name string) int {
+	for i, s := range sections {
+		if strings.EqualFold(s.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// layoutID returns an HTML safe id for a tab or section
+func layoutID(objectName string, name string) string {
+	return strings.ToLower(core.RemoveSpecialChars(objectName + "-" + name))
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_setupFieldLayout(t *testing.T) {
+	e := ObjectDefinition{ObjectName: "Test", FieldsList: []FieldProperties{
+		{FieldName: "A"},
+		{FieldName: "B", Section: "Detail", Tab: "Main"},
+		{FieldName: "C", DisplayOrder: 5},
+		{FieldName: "D", Section: "Detail", Tab: "Main"},
+	}}
+	e = setupFieldLayout(e)
+
+	order := ""
+	for _, f := range e.FieldsList {
+		order = order + f.FieldName
+	}
+	if order != "ABCD" {
+		t.Errorf("setupFieldLayout() field list order = %v, want %v", order, "ABCD")
+	}
+	order = ""
+	for _, s := range e.Sections {
+		for _, f := range s.Fields {
+			order = order + f.FieldName
+		}
+	}
+	if order != "CABD" {
+		t.Errorf("setupFieldLayout() layout order = %v, want %v", order, "CABD")
+	}
+	if !e.HasTabs || !e.HasSections {
+		t.Errorf("setupFieldLayout() HasTabs = %v, HasSections = %v, want true", e.HasTabs, e.HasSections)
+	}
+	tests := []struct {
+		name     string
+		tab      string
+		sections int
+		fields   int
+	}{
+		{"Test 1", layoutDefaultTab, 1, 2},
+		{"Test 2", "Main", 1, 2},
+	}
+	if len(e.Tabs) != len(tests) {
+		t.Fatalf("setupFieldLayout() tabs = %v, want %v", len(e.Tabs), len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := e.Tabs[i]
+			if got.Name != tt.tab || len(got.Sections) != tt.sections || len(got.Sections[0].Fields) != tt.fields {
+				t.Errorf("setupFieldLayout() tab = %v (%v sections, %v fields), want %v (%v, %v)", got.Name, len(got.Sections), len(got.Sections[0].Fields), tt.tab, tt.sections, tt.fields)
+			}
+		})
+	}
+}
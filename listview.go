@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mt1976/mwt-goToolkit/logs"
+)
+
+const (
+	sortHREFString    = "?sort=%[1]s&dir={{if and (eq $.Query.Sort %[1]q) (eq $.Query.Direction \"asc\")}}desc{{else}}asc{{end}}{{if $.Query.Params}}&{{$.Query.Params}}{{end}}"
+	sortIconString    = "{{if eq $.Query.Sort %[1]q}}<i class=\"fas fa-sort-{{if eq $.Query.Direction \"desc\"}}down{{else}}up{{end}} ms-1\"></i>{{end}}"
+	filterValueString = "{{index $.Query.Filters %q}}"
+)
+
+// listOverrides applies the list view columns (InList, Sortable, Searchable, Filterable, SortDefault & Width)
+// of an enrichment definition to a field
+func listOverrides(record []string, field FieldProperties) FieldProperties {
+	if record[enri_InList] == "false" {
+		field.IsListExcluded = true
+	}
+	if record[enri_InList] == "true" {
+		field.IsListExcluded = false
+	}
+	if record[enri_Sortable] != "" {
+		field.IsSortable = record[enri_Sortable] == "true"
+	}
+	if record[enri_Searchable] != "" {
+		field.IsSearchable = record[enri_Searchable] == "true"
+	}
+	if record[enri_Filterable] != "" {
+		field.IsFilterable = record[enri_Filterable] == "true"
+	}
+	switch strings.ToLower(strings.TrimSpace(record[enri_SortDefault])) {
+	case "asc":
+		field.SortDirection = "ASC"
+	case "desc":
+		field.SortDirection = "DESC"
+	case "":
+	default:
+		logs.Warning("Invalid SortDefault " + record[enri_SortDefault] + " for " + field.FieldName + ", use asc or desc")
+	}
+	if record[enri_Width] != "" {
+		field.ColumnWidth = strings.TrimSpace(record[enri_Width])
+	}
+	return field
+}
+
+// setupListView decides which fields are shown on the list page, and which can be sorted, searched & filtered on the server.
+// Only fields held in the database can be used in a server side query.
+func setupListView(e ObjectDefinition) ObjectDefinition {
+	var defaultSort []string
+	for i, f := range e.FieldsList {
+		f.InList = f.IsUserField && (f.IsBaseField || f.IsExtra) && f.Hidden == "" && !f.IsListExcluded
+		if !f.IsBaseField || e.HasFetchAdaptor {
+			if f.IsSortable || f.IsSearchable || f.IsFilterable || f.SortDirection != "" {
+				logs.Warning(f.FieldName + " is not held in the database, it cannot be sorted, searched or filtered")
+			}
+			f.IsSortable = false
+			f.IsSearchable = false
+			f.IsFilterable = false
+			f.SortDirection = ""
+		}
+		if f.IsSortable {
+			f.SortHREF = fmt.Sprintf(sortHREFString, f.FieldName)
+			f.SortIcon = fmt.Sprintf(sortIconString, f.FieldName)
+			e.HasSortable = true
+		}
+		if f.SortDirection != "" {
+			defaultSort = append(defaultSort, f.FieldSQL+" "+f.SortDirection)
+		}
+		if f.IsFilterable {
+			f.FilterValue = fmt.Sprintf(filterValueString, f.FieldName)
+		}
+		e.HasSearchable = e.HasSearchable || f.IsSearchable
+		e.HasFilterable = e.HasFilterable || f.IsFilterable
+		e.FieldsList[i] = f
+	}
+	e.DefaultSort = strings.Join(defaultSort, ", ")
+	if e.HasPagination && e.DefaultSort == "" {
+		// Paging needs a stable order, so fall back to the table key
+		e.DefaultSort = e.SQLSearchID + " ASC"
+	}
+	e.HasListQuery = e.HasSortable || e.HasSearchable || e.HasFilterable || e.DefaultSort != "" || e.HasPagination
+	if e.HasListQuery {
+		e.QuerySearchWc = wrapVariable("Query.Search")
+		e.QuerySortWc = wrapVariable("Query.Sort")
+		e.QueryDirectionWc = wrapVariable("Query.Direction")
+	}
+	return e
+}
+
+// setupImports works out any additional imports the generated dao needs
+func setupImports(e ObjectDefinition) ObjectDefinition {
+	imports := e.ComputedImports
+	if e.HasListQuery {
+		imports = append(imports, "strings")
+	}
+	if e.HasPagination {
+		imports = append(imports, "strconv")
+	}
+	if e.HasMoney {
+		imports = append(imports, "math/big", "strconv", "strings")
+	}
+	if e.HasRequired {
+		imports = append(imports, "strings")
+	}
+	if e.HasPatterns {
+		imports = append(imports, "regexp")
+	}
+	if e.HasRanges {
+		imports = append(imports, "math/big", "strings")
+	}
+	if e.HasLengths {
+		imports = append(imports, "unicode/utf8")
+	}
+	e.DaoImports = uniqueStrings(imports)
+	return e
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_setupListView(t *testing.T) {
+	e := ObjectDefinition{ObjectName: "Test", FieldsList: []FieldProperties{
+		{FieldName: "Code", FieldSQL: "Code", IsUserField: true, IsBaseField: true, IsSortable: true, SortDirection: "ASC"},
+		{FieldName: "Name", FieldSQL: "Name", IsUserField: true, IsBaseField: true, IsSearchable: true, SortDirection: "DESC"},
+		{FieldName: "Notes", FieldSQL: "Notes", IsUserField: true, IsBaseField: true, IsListExcluded: true},
+		{FieldName: "Total", IsUserField: true, IsExtra: true, IsSortable: true, IsFilterable: true},
+	}}
+	e = setupListView(e)
+
+	if e.DefaultSort != "Code ASC, Name DESC" {
+		t.Errorf("setupListView() DefaultSort = %v, want %v", e.DefaultSort, "Code ASC, Name DESC")
+	}
+	if !e.HasListQuery || !e.HasSortable || !e.HasSearchable || e.HasFilterable {
+		t.Errorf("setupListView() HasListQuery = %v, HasSortable = %v, HasSearchable = %v, HasFilterable = %v", e.HasListQuery, e.HasSortable, e.HasSearchable, e.HasFilterable)
+	}
+	if !strings.Contains(e.FieldsList[0].SortHREF, "$.Query.Params") {
+		t.Errorf("setupListView() SortHREF = %v, want the search & filters kept", e.FieldsList[0].SortHREF)
+	}
+	tests := []struct {
+		name     string
+		inList   bool
+		sortable bool
+	}{
+		{"Test 1", true, true},
+		{"Test 2", true, false},
+		{"Test 3", false, false},
+		{"Test 4", true, false},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := e.FieldsList[i]
+			if got.InList != tt.inList || got.IsSortable != tt.sortable || (got.SortHREF != "") != tt.sortable {
+				t.Errorf("setupListView() %v InList = %v, IsSortable = %v, want %v, %v", got.FieldName, got.InList, got.IsSortable, tt.inList, tt.sortable)
+			}
+		})
+	}
+}
@@ -62,14 +62,23 @@ func main() {
 
 	logs.Break()
 
+	var objects []ObjectDefinition
 	for i := 0; i < noFiles; i++ {
 		// if last four character in paths[i] are ".cfg" then proceed otherwise skip this item
 		fileExtension := paths[i][len(paths[i])-4:]
 		//fmt.Println(fileExtension) // gives "lo"
 		if fileExtension == ".cfg" {
-			processObjectDefinition(paths[i])
+			objects = append(objects, processObjectDefinition(paths[i]))
 		}
 	}
+
+	// Project artifacts need every object, so they are created last, and only when every object has been read
+	if clItem == "" {
+		generateProjectArtifacts(objects)
+	} else {
+		logs.Warning("The project artifacts need every object, they are not generated for a single object")
+	}
+
 	logs.Break()
 	logs.Success("Templating Complete")
 	logs.Break()
@@ -94,7 +103,7 @@ func seekTableDefinitions(dir string) []string {
 	return paths
 }
 
-func processObjectDefinition(configFile string) {
+func processObjectDefinition(configFile string) ObjectDefinition {
 	logs.Processing(configFile)
 	//	logs.Information("Populate", "Replacement Values")
 	//logs.Information("sausage", "")
@@ -108,6 +117,7 @@ func processObjectDefinition(configFile string) {
 	enriPath := getPWD() + data_in() + "/" + e.ObjectName + ".enri"
 	logs.Information("CSV  Path", csvPath)
 	logs.Information("Enri Path", enriPath)
+	e.SourceHash = hashSources(configFile, csvPath, enriPath)
 
 	if props["use"] == "db" {
 		// Do nothing for now
@@ -131,6 +141,23 @@ func processObjectDefinition(configFile string) {
 		logs.Information("Getting Enrichment Fields from enri", enriPath)
 		e = mergeEnrichmentDefinitions(enriPath, e)
 	}
+	if e.HasMemoryStore && e.HasJunctions {
+		// The links of a junction are kept in a table of their own, which only the database holds
+		logs.Warning(e.ObjectName + " has junctions, an in-memory storage cannot stand in for it")
+		e.HasMemoryStore = false
+	}
+
+	e = setupMoneyFields(e)
+	e = setupTemporalFields(e)
+	e = setupPagination(e)
+	e = setupFieldSecurity(e)
+	e = setupListView(e)
+	e = setupFieldChecks(e)
+	e = setupImport(e)
+	e = setupImports(e)
+	e = setupFieldLabels(e)
+	e = setupTranslations(e)
+	e = setupFieldLayout(e)
 
 	// for i := 0; i < len(e.FieldsList); i++ {
 	// 	logs.Information(e.FieldsList[i].FieldName, strconv.Itoa(i))
@@ -147,23 +174,48 @@ func processObjectDefinition(configFile string) {
 
 	if e.CanAPI {
 		e = generateCodeArtifact("api", props, configFile, e)
+		e = generateCodeArtifact("typescript", props, configFile, e)
 	}
 
 	e = generateCodeArtifact("dao", props, configFile, e)
 
+	if e.HasMemoryStore {
+		e = processCodeArtifact("memory", configFile, "memory", e)
+	}
+
+	if e.CanImport {
+		e = processCodeArtifact("import", configFile, "import", e)
+	}
+
 	e = generateCodeArtifact("datamodel", props, configFile, e)
 
+	e = generateTestArtifacts(configFile, e)
+
 	e = generateCodeArtifact("job", props, configFile, e)
 
 	e = generateCodeArtifact("menu", props, configFile, e)
 
 	e = generateHTMLArtifacts("html", props, configFile, e)
 
+	if e.CanAPI {
+		e = generateOpenAPIArtifact(e)
+	}
+
+	e = generateSchemaArtifact(e)
+
+	e = generateTranslationArtifact(e)
+
+	if e.HasJunctions {
+		// The junction tables are always needed, so their SQL is always generated
+		e = processCodeArtifact("sql", configFile, "sql", e)
+	}
+
 	e = generateCodeArtifact("catalog", props, configFile, e)
 
 	e = generateCodeArtifact("monitor", props, configFile, e)
 
 	//spew.Dump(e)
+	return e
 }
 
 func generateCodeArtifact(a string, props map[string]string, configFile string, e ObjectDefinition) ObjectDefinition {
@@ -204,10 +256,21 @@ func processCodeArtifact(w string, p string, destFolder string, e ObjectDefiniti
 		in_extn = ".json_template"
 	}
 
+	if destFolder == "sql" {
+		destFolder = "design/sql"
+		out_extn = ".sql"
+		in_extn = ".sql_template"
+	}
+
 	if destFolder == "html" {
 		in_extn = ".html_template"
 	}
 
+	if destFolder == "typescript" {
+		out_extn = ".ts"
+		in_extn = ".ts_template"
+	}
+
 	if destFolder == "monitor" {
 		destFolder = "routes"
 		out_extn = "_monitor_impl.go_template"
@@ -226,6 +289,26 @@ func processCodeArtifact(w string, p string, destFolder string, e ObjectDefiniti
 		in_extn = ".go_template"
 	}
 
+	if destFolder == "memory" {
+		destFolder = "dao"
+		out_extn = "_memory.go"
+	}
+
+	if destFolder == "import" {
+		destFolder = "dao"
+		out_extn = "_import.go"
+	}
+
+	if destFolder == "daotest" {
+		destFolder = "dao"
+		out_extn = "_core_test.go"
+	}
+
+	if destFolder == "datamodeltest" {
+		destFolder = "datamodel"
+		out_extn = "_core_test.go"
+	}
+
 	if destFolder == "validation" {
 		destFolder = "dao"
 		out_extn = "_validation.go_template"
@@ -318,6 +401,10 @@ func generateHTMLArtifacts(a string, props map[string]string, configFile string,
 			logs.Skipping("Creating is not enabled for this object")
 		}
 
+		if e.CanImport {
+			e = generateHTMLArtifact("import", configFile, destinationFolder, e)
+		}
+
 		if !e.CanExport {
 			logs.Skipping("Exporting is not enabled for this object")
 		}
@@ -335,8 +422,10 @@ func generateHTMLArtifact(w string, p string, destFolder string, e ObjectDefinit
 
 	//spew.Dump(replacements)
 	fp := e.Path + "/templates/" + w + html_template
+	// The layout template renders the fields as tabs & sections, and is shared by all the HTML templates
+	lp := e.Path + "/templates/" + layoutTemplate + html_template
 
-	t, err := template.ParseFiles(fp)
+	t, err := template.ParseFiles(fp, lp)
 	if err != nil {
 		logs.Error("Load Template", err)
 	}
@@ -454,6 +543,10 @@ func getFieldDefinitions_DB(e ObjectDefinition, p map[string]string) ObjectDefin
 		logs.Success("Fields Found =" + strconv.Itoa(noFields))
 	}
 	//displayTableHeader("Table")
+	var columns []string
+	for _, row := range results {
+		columns = append(columns, row["COLUMN_NAME"].(string))
+	}
 	for _, row := range results {
 		colName := row["COLUMN_NAME"].(string)
 		colType := row["TYPE_NAME"].(string)
@@ -477,12 +570,27 @@ func getFieldDefinitions_DB(e ObjectDefinition, p map[string]string) ObjectDefin
 		case "decimal", "numeric":
 			colDefault = "0.00"
 			colType = "Float"
-		case "datetime", "smalldatetime", "date", "time", "datetime2", "datetimeoffset":
+			if hasCurrencyColumn(colName, columns) {
+				colType = moneyType
+			}
+		case "datetime", "smalldatetime", "datetime2":
+			colDefault = ""
+			colType = "DateTime"
+		case "date":
+			colDefault = ""
+			colType = "Date"
+		case "time":
 			colDefault = ""
 			colType = "Time"
-		case "float", "real", "money", "smallmoney":
+		case "datetimeoffset":
+			colDefault = ""
+			colType = "Timestamp"
+		case "float", "real":
 			colDefault = "0.00"
 			colType = "Float"
+		case "money", "smallmoney":
+			colDefault = "0.00"
+			colType = moneyType
 		case "int identity":
 			colDefault = "0"
 			colType = "Int"
@@ -565,6 +673,13 @@ func setupObjectEnrichment(props map[string]string) ObjectDefinition {
 	// 	e.HasStoreAdaptor = true
 	// }
 	e.HasFetchAdaptor = getProperty("hasfetchadaptor", props)
+	e.HasPagination = getProperty("paginate", props)
+	e.PageSize = getPageSize(props)
+	e.SeedCount = getSeedCount(props)
+	e.SQLDialect = getSQLDialect(props)
+	e.Timezone = getTimezone(props)
+	e.MenuSection = strings.TrimSpace(props["menusection"])
+	e.MenuOrder = getMenuOrder(props)
 
 	// e.HasFetchAdaptor = false
 	// if props["hasfetchadaptor"] == "y" {
@@ -604,6 +719,7 @@ func setupObjectEnrichment(props map[string]string) ObjectDefinition {
 	e = setupTemplateEnrichment(e, props)
 
 	e = setupPermissions(e, props)
+	e = setupRoles(e, props)
 
 	e.ProvidesReverseLookup = false
 	e.ReverseLookup = ""
@@ -681,6 +797,20 @@ func setupPermissions(e ObjectDefinition, props map[string]string) ObjectDefinit
 	e.CanAPI = getProperty("can_api", props)
 	e.CanDo = getProperty("can_do", props)
 	e.CanSoftDelete = getProperty("can_softdelete", props)
+	e.HasGraphQL = getProperty("create_graphql", props)
+	e.HasProto = getProperty("create_proto", props)
+	if getProperty("create_memory", props) {
+		if e.HasStoreAdaptor || e.HasFetchAdaptor {
+			logs.Warning(e.ObjectName + " is kept by an adaptor, an in-memory storage cannot stand in for it")
+		} else {
+			e.HasMemoryStore = true
+		}
+	}
+	e.HasSeed = getProperty("create_seed", props)
+	e.HasTests = getProperty("create_tests", props)
+	e.CanImport = getProperty("can_import", props)
+	e.ExportFormats = getExportFormats(props)
+	e.ExportsCSV = hasExportFormat(e, "csv")
 	//if e.CanSoftDelete {
 	//	e.CanDelete = false
 	//}
@@ -799,6 +929,10 @@ func mergeEnrichmentDefinitions(filePath string, en ObjectDefinition) ObjectDefi
 	//logs.Information("File Open", filePath)
 	// Create a new reader.
 	r := csv.NewReader(f)
+	// Older enrichment files may not have all the columns, these are padded below
+	r.FieldsPerRecord = -1
+	// Computed expressions may contain quotes, e.g. FirstName + " " + LastName
+	r.LazyQuotes = true
 	//logs.Information("New Reader", filePath)
 	//displayTableHeader("Enrichment")
 	var enrichmentDefinitions [][]string
@@ -819,14 +953,21 @@ func mergeEnrichmentDefinitions(filePath string, en ObjectDefinition) ObjectDefi
 
 			panic(err)
 		}
+		enrichmentDefinition = padEnrichment(enrichmentDefinition)
 		if enrichmentDefinition[enri_Type] == "Type" && enrichmentDefinition[enri_Field] == "Field" {
 			//logs.Information("Found", "Enrichments")
 		} else {
 
-			if enrichmentType(enrichmentDefinition[enri_Type], extraField) {
-				// Add additional "Extra" fields to the object definition as specfied in .../?.enri
+			if enrichmentType(enrichmentDefinition[enri_Type], extraField) || enrichmentType(enrichmentDefinition[enri_Type], computedField) {
+				// Add additional "Extra" & "Computed" fields to the object definition as specfied in .../?.enri
 				//			logs.Information("Found", "Extra Field")
 				en.FieldsList = addExtraTypeFields(enrichmentDefinition, en)
+			} else if enrichmentType(enrichmentDefinition[enri_Type], childField) {
+				// Child relationships are not fields, they are shown as a grid on the view page
+				en = addChildRelation(enrichmentDefinition, en)
+			} else if enrichmentType(enrichmentDefinition[enri_Type], junctionField) {
+				// Many-to-many relationships are held in a junction table, they are chosen with a multi-select on the edit page
+				en = addJunctionRelation(enrichmentDefinition, en)
 			} else {
 				// add enrichmentDefinition to enrichmentDefinitions list
 
@@ -861,6 +1002,10 @@ func mergeEnrichmentDefinitions(filePath string, en ObjectDefinition) ObjectDefi
 			// Do Nothing
 			logs.Information(extraField, thisEnrichment[enri_Field])
 
+		case enrichmentType(thisEnrichment[enri_Type], computedField):
+			// Do Nothing
+			logs.Information(computedField, thisEnrichment[enri_Field])
+
 		case enrichmentType(thisEnrichment[enri_Type], overrideField):
 			// Do Nothing
 			//logs.Information(overrideField, thisEnrichment[enri_Field])
@@ -882,6 +1027,9 @@ func mergeEnrichmentDefinitions(filePath string, en ObjectDefinition) ObjectDefi
 			logs.Warning("Unkown Enrichment Type" + thisEnrichment[enri_Type] + thisEnrichment[enri_Field])
 		}
 	}
+
+	en = setupComputedFields(en)
+
 	noRows := len(en.FieldsList)
 	for i := 0; i < noRows; i++ {
 		//fmt.Printf("AF en: %d %v\n", i, en.FieldsList[i])
@@ -894,6 +1042,8 @@ func mergeEnrichmentDefinitions(filePath string, en ObjectDefinition) ObjectDefi
 			lkVal = "LL"
 		case op.IsFetch:
 			lkVal = "FL"
+		case op.IsComputed:
+			lkVal = "CF"
 		}
 		//fmt.Printf("lkVal: %v\n", lkVal)
 		ipVal := "Y"
@@ -949,6 +1099,14 @@ func addExtraTypeFields(record []string, en ObjectDefinition) []FieldProperties
 		suffix = ""
 	}
 
+	// Computed fields are virtual, like Extra fields, so they are never written to the database
+	isComputed := false
+	if enrichmentType(record[enri_Type], computedField) {
+		isExtra = true
+		isComputed = true
+		suffix = ""
+	}
+
 	if enrichmentType(record[enri_Type], overrideField) {
 		isOverride = true
 		suffix = ""
@@ -978,7 +1136,33 @@ func addExtraTypeFields(record []string, en ObjectDefinition) []FieldProperties
 		isFilteredLookup = true
 	}
 
-	return addComplexField(en, record[enri_Field]+suffix, "String", record[enri_DefaultValue], colMand, false, isLookup, lkObject, lkKeyField, lkValueField, lkRange, noInput, isExtra, isOverride, isListLookup, isFetch, isHidden, isFilteredLookup)
+	fields := addComplexField(en, record[enri_Field]+suffix, "String", record[enri_DefaultValue], colMand, false, isLookup, lkObject, lkKeyField, lkValueField, lkRange, noInput, isExtra, isOverride, isListLookup, isFetch, isHidden, isFilteredLookup)
+
+	last := len(fields) - 1
+	fields[last] = layoutOverrides(record, fields[last])
+	fields[last] = listOverrides(record, fields[last])
+	fields[last] = moneyOverrides(record, fields[last])
+	fields[last] = securityOverrides(record, fields[last])
+	fields[last] = labelOverrides(record, fields[last])
+	fields[last] = schemaOverrides(record, fields[last])
+
+	if isComputed {
+		// Computed fields are display only, the value is derived from the expression after a fetch
+		fields[last].IsComputed = true
+		fields[last].Expression = record[enri_Expression]
+		fields[last].Disabled = html_disabled
+		fields[last].IsNoChange = true
+		fields[last].HasCallout = false
+	}
+	return fields
+}
+
+// padEnrichment ensures an enrichment definition has all the columns, older .enri files have fewer columns
+func padEnrichment(record []string) []string {
+	for len(record) < enri_Columns {
+		record = append(record, "")
+	}
+	return record
 }
 
 func buildRangeHTML(inObject string) string {
@@ -1109,6 +1293,12 @@ func commonOverrides(commonOverrides []string, fieldsList FieldProperties) Field
 	if commonOverrides[enri_Filter] == "true" {
 		fieldsList.IsFilteredLookup = true
 	}
+	fieldsList = layoutOverrides(commonOverrides, fieldsList)
+	fieldsList = listOverrides(commonOverrides, fieldsList)
+	fieldsList = moneyOverrides(commonOverrides, fieldsList)
+	fieldsList = securityOverrides(commonOverrides, fieldsList)
+	fieldsList = labelOverrides(commonOverrides, fieldsList)
+	fieldsList = schemaOverrides(commonOverrides, fieldsList)
 	//}
 	return fieldsList
 }
@@ -30,3 +30,20 @@ func Test_isAudit(t *testing.T) {
 		})
 	}
 }
+
+func Test_padEnrichment(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []string
+	}{
+		{"Test 1", []string{"Override", "Name"}},
+		{"Test 2", make([]string, enri_Columns)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := padEnrichment(tt.in); len(got) != enri_Columns {
+				t.Errorf("padEnrichment() = %v columns, want %v", len(got), enri_Columns)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mt1976/mwt-goToolkit/logs"
+)
+
+const manifestFile = "design/manifest.json"
+
+// manifest records what was generated for each object, it is kept between runs so that a run can report what changed
+type manifest struct {
+	Version string                    `json:"version"`
+	Date    string                    `json:"date"`
+	Time    string                    `json:"time"`
+	Objects map[string]manifestObject `json:"objects"`
+}
+
+type manifestObject struct {
+	Hash          string         `json:"hash"`
+	Fields        []string       `json:"fields"`
+	Artifacts     []string       `json:"artifacts"`
+	Proto         map[string]int `json:"proto,omitempty"`
+	ProtoReserved []int          `json:"protoReserved,omitempty"`
+}
+
+// change is an entry in the changelog of a run
+type change struct {
+	Object string
+	Camel  string
+	Change string
+	Detail string
+}
+
+// hashSources returns a hash of the object definition files, missing files are skipped
+func hashSources(paths ...string) string {
+	h := sha256.New()
+	for _, p := range paths {
+		content, err := os.ReadFile(p)
+		if err != nil {
+			continue
+		}
+		h.Write(content)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// loadManifest reads the manifest of the previous run, an empty manifest is returned if there is none
+func loadManifest(path string) manifest {
+	m := manifest{Objects: make(map[string]manifestObject)}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return m
+	}
+	if err := json.Unmarshal(content, &m); err != nil {
+		logs.Warning("Invalid manifest " + path + ", it will be replaced : " + err.Error())
+		return manifest{Objects: make(map[string]manifestObject)}
+	}
+	if m.Objects == nil {
+		m.Objects = make(map[string]manifestObject)
+	}
+	return m
+}
+
+// saveManifest writes the manifest of this run
+func saveManifest(path string, m manifest) {
+	content, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		logs.Error("Manifest : ", err)
+		return
+	}
+	if err := os.WriteFile(path, append(content, '\n'), 0600); err != nil {
+		logs.Error("Manifest : ", err)
+		return
+	}
+	logs.Created(path)
+}
+
+// newManifestObject describes an object as it was generated
+func newManifestObject(o ObjectDefinition) manifestObject {
+	mo := manifestObject{Hash: o.SourceHash}
+	for _, f := range o.FieldsList {
+		mo.Fields = append(mo.Fields, f.FieldName)
+	}
+	for _, a := range o.Artifacts {
+		mo.Artifacts = append(mo.Artifacts, a.Path)
+	}
+	return mo
+}
+
+// updateManifest adds the objects of this run to the previous manifest and returns what changed.
+// Objects that were not processed this run are kept, unless their definition no longer exists.
+func updateManifest(previous manifest, objects []ObjectDefinition, exists func(string) bool) (manifest, []change) {
+	current := manifest{Objects: make(map[string]manifestObject)}
+	var changes []change
+	for _, o := range objects {
+		mo := newManifestObject(o)
+		old, found := previous.Objects[o.ObjectName]
+		mo.Proto, mo.ProtoReserved = numberProtoFields(old, mo.Fields)
+		current.Objects[o.ObjectName] = mo
+		switch {
+		case !found:
+			changes = append(changes, change{Object: o.ObjectName, Camel: o.ObjectCamelCase, Change: "Added", Detail: strings.Join(mo.Fields, ", ")})
+		case old.Hash != mo.Hash || strings.Join(old.Fields, ",") != strings.Join(mo.Fields, ","):
+			changes = append(changes, change{Object: o.ObjectName, Camel: o.ObjectCamelCase, Change: "Changed", Detail: fieldChanges(old.Fields, mo.Fields)})
+		}
+	}
+	var names []string
+	for name := range previous.Objects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, done := current.Objects[name]; done {
+			continue
+		}
+		if exists(name) {
+			current.Objects[name] = previous.Objects[name]
+			continue
+		}
+		changes = append(changes, change{Object: name, Change: "Removed"})
+	}
+	return current, changes
+}
+
+// fieldChanges describes the fields added & removed between two versions of an object
+func fieldChanges(before []string, after []string) string {
+	was := make(map[string]bool)
+	for _, f := range before {
+		was[f] = true
+	}
+	is := make(map[string]bool)
+	for _, f := range after {
+		is[f] = true
+	}
+	var added, removed []string
+	for _, f := range after {
+		if !was[f] {
+			added = append(added, f)
+		}
+	}
+	for _, f := range before {
+		if !is[f] {
+			removed = append(removed, f)
+		}
+	}
+	var detail []string
+	if len(added) > 0 {
+		detail = append(detail, "Fields added: "+strings.Join(added, ", "))
+	}
+	if len(removed) > 0 {
+		detail = append(detail, "Fields removed: "+strings.Join(removed, ", "))
+	}
+	if len(detail) == 0 {
+		return "Definition changed"
+	}
+	return strings.Join(detail, "; ")
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_updateManifest(t *testing.T) {
+	previous := manifest{Objects: map[string]manifestObject{
+		"Project":      {Hash: "1", Fields: []string{"ProjectID", "Name"}},
+		"ProjectState": {Hash: "2", Fields: []string{"ProjectStateID"}},
+		"Origin":       {Hash: "3", Fields: []string{"OriginID"}},
+		"Retired":      {Hash: "4", Fields: []string{"RetiredID"}},
+	}}
+	objects := []ObjectDefinition{
+		{ObjectName: "Project", SourceHash: "5", FieldsList: []FieldProperties{{FieldName: "ProjectID"}, {FieldName: "Budget"}}},
+		{ObjectName: "ProjectState", SourceHash: "2", FieldsList: []FieldProperties{{FieldName: "ProjectStateID"}}},
+		{ObjectName: "ProjectAction", SourceHash: "6", FieldsList: []FieldProperties{{FieldName: "ProjectActionID"}}},
+	}
+	exists := func(name string) bool { return name == "Origin" }
+	current, changes := updateManifest(previous, objects, exists)
+
+	if len(current.Objects) != 4 {
+		t.Errorf("updateManifest() objects = %v, want %v", len(current.Objects), 4)
+	}
+	tests := []struct {
+		name   string
+		object string
+		change string
+		detail string
+	}{
+		{"Test 1", "Project", "Changed", "Fields added: Budget; Fields removed: Name"},
+		{"Test 2", "ProjectAction", "Added", "ProjectActionID"},
+		{"Test 3", "Retired", "Removed", ""},
+	}
+	if len(changes) != len(tests) {
+		t.Fatalf("updateManifest() changes = %v, want %v", changes, len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := changes[i]
+			if got.Object != tt.object || got.Change != tt.change || got.Detail != tt.detail {
+				t.Errorf("updateManifest() = %v, want %v %v %v", got, tt.object, tt.change, tt.detail)
+			}
+		})
+	}
+}
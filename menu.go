@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/google/uuid"
+	core "github.com/mt1976/mwt-goToolkit/core"
+	"github.com/mt1976/mwt-goToolkit/logs"
+)
+
+const menuSectionDefault = "General"
+
+// menuSection is a group of the project menu, its values are JSON encoded ready for the template
+type menuSection struct {
+	Name  string
+	Items []menuItem
+}
+
+type menuItem struct {
+	ID        string
+	Text      string
+	Glyph     string
+	HREF      string
+	TextClass string
+	Order     int
+	Roles     string
+}
+
+// getMenuOrder returns the menuorder property, items without an order follow those with one
+func getMenuOrder(props map[string]string) int {
+	if props["menuorder"] == "" {
+		return 0
+	}
+	order, err := strconv.Atoi(strings.TrimSpace(props["menuorder"]))
+	if err != nil || order < 1 {
+		logs.Warning("Invalid menuorder " + props["menuorder"] + ", it has been ignored")
+		return 0
+	}
+	return order
+}
+
+// buildMenu groups every listable object by its menu section. The sections are in the order of the
+// menusections property (application.cfg), then by name; the items by their menuorder, then by name.
+// A role menu only has the objects the role may list, no role gives the full menu.
+func buildMenu(objects []ObjectDefinition, sectionOrder []string, role string) []menuSection {
+	grouped := make(map[string][]ObjectDefinition)
+	for _, o := range objects {
+		if !o.CanList || (role != "" && !roleAllowed(listRoles(o), role)) {
+			continue
+		}
+		section := o.MenuSection
+		if section == "" {
+			section = menuSectionDefault
+		}
+		grouped[section] = append(grouped[section], o)
+	}
+
+	rank := make(map[string]int)
+	for i, s := range sectionOrder {
+		rank[strings.ToLower(strings.TrimSpace(s))] = i + 1
+	}
+	var names []string
+	for name := range grouped {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		ri, rj := rank[strings.ToLower(names[i])], rank[strings.ToLower(names[j])]
+		if ri != rj {
+			return ri != 0 && (rj == 0 || ri < rj)
+		}
+		return names[i] < names[j]
+	})
+
+	var menu []menuSection
+	for _, name := range names {
+		items := grouped[name]
+		sort.SliceStable(items, func(i, j int) bool {
+			oi, oj := items[i].MenuOrder, items[j].MenuOrder
+			if oi != oj {
+				return oi != 0 && (oj == 0 || oi < oj)
+			}
+			return items[i].FriendlyName < items[j].FriendlyName
+		})
+		section := menuSection{Name: jsonString(name)}
+		for _, o := range items {
+			roles := listRoles(o)
+			if roles == nil {
+				roles = []string{}
+			}
+			menuRoles, _ := json.Marshal(roles)
+			section.Items = append(section.Items, menuItem{
+				ID:        jsonString(uuid.NewSHA1(uuid.NameSpaceURL, []byte(o.ProjectRepo+o.ObjectName)).String()),
+				Text:      jsonString(o.FriendlyName),
+				Glyph:     jsonString(o.ObjectGlyph),
+				HREF:      jsonString("/" + o.EndpointRoot + "List"),
+				TextClass: jsonString(o.ObjectTextClass),
+				Order:     o.MenuOrder,
+				Roles:     string(menuRoles),
+			})
+		}
+		menu = append(menu, section)
+	}
+	return menu
+}
+
+// jsonString returns a string as a quoted JSON value
+func jsonString(in string) string {
+	out, _ := json.Marshal(in)
+	return string(out)
+}
+
+// generateMenuArtifacts writes the project menu, and a menu for each role in the folder of the role (see core.GetMenuID)
+func generateMenuArtifacts(p projectDefinition) {
+	writeMenu(p, "")
+	for _, role := range p.Roles {
+		p.Menu = buildMenu(p.Objects, strings.Split(core.Properties["menusections"], ","), role)
+		writeMenu(p, role)
+	}
+}
+
+// writeMenu writes a menu, it is only written if it is valid JSON
+func writeMenu(p projectDefinition, role string) {
+	t, err := template.ParseFiles(p.Path + "/templates/menus" + json_template)
+	if err != nil {
+		logs.Error("Load Template :", err)
+		return
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, p); err != nil {
+		logs.Error("Process Template", err)
+		return
+	}
+	var check []interface{}
+	if err := json.Unmarshal(buf.Bytes(), &check); err != nil {
+		logs.Error("Menu is not valid JSON, it has not been written : ", err)
+		return
+	}
+	var out bytes.Buffer
+	json.Indent(&out, buf.Bytes(), "", "  ")
+	out.WriteString("\n")
+
+	fullPath := data_out() + "/design/menu"
+	if role != "" {
+		fullPath = fullPath + "/" + role
+	}
+	if _, err := os.Stat(fullPath); os.IsNotExist(err) {
+		logs.Created(fullPath)
+		os.MkdirAll(fullPath, 0700)
+	}
+	name := fullPath + "/menu.json"
+	if core.Properties["deliverto"] == "" {
+		name = name + "_tmp"
+	}
+	if err := os.WriteFile(name, out.Bytes(), 0600); err != nil {
+		logs.Error("Create file : ", err)
+		return
+	}
+	logs.Created(name)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func Test_buildMenu(t *testing.T) {
+	objects := []ObjectDefinition{
+		{ObjectName: "Project", FriendlyName: "Project", CanList: true, MenuSection: "Projects", MenuOrder: 2},
+		{ObjectName: "ProjectAction", FriendlyName: "Project \"Action\"", CanList: true, MenuSection: "Projects", MenuOrder: 1},
+		{ObjectName: "Origin", FriendlyName: "Origin", CanList: true, MenuSection: "Projects"},
+		{ObjectName: "ProjectState", FriendlyName: "Project State", CanList: true, MenuSection: "Admin"},
+		{ObjectName: "Translation", FriendlyName: "Translation", CanList: true},
+		{ObjectName: "Session", FriendlyName: "Session"},
+	}
+	menu := buildMenu(objects, []string{"projects", " Admin"}, "")
+
+	tests := []struct {
+		name    string
+		section string
+		items   []string
+	}{
+		{"Test 1", "Projects", []string{"Project \"Action\"", "Project", "Origin"}},
+		{"Test 2", "Admin", []string{"Project State"}},
+		{"Test 3", menuSectionDefault, []string{"Translation"}},
+	}
+	if len(menu) != len(tests) {
+		t.Fatalf("buildMenu() = %v sections, want %v", len(menu), len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var section string
+			json.Unmarshal([]byte(menu[i].Name), &section)
+			if section != tt.section || len(menu[i].Items) != len(tt.items) {
+				t.Fatalf("buildMenu() = %v %v, want %v %v", section, len(menu[i].Items), tt.section, len(tt.items))
+			}
+			for j, want := range tt.items {
+				var got string
+				json.Unmarshal([]byte(menu[i].Items[j].Text), &got)
+				if got != want {
+					t.Errorf("buildMenu() item %v = %v, want %v", j, got, want)
+				}
+			}
+		})
+	}
+}
+
+func Test_buildMenu_role(t *testing.T) {
+	objects := []ObjectDefinition{
+		setupRoles(ObjectDefinition{ObjectName: "Project", CanList: true}, map[string]string{"roles_list": "admin,manager"}),
+		setupRoles(ObjectDefinition{ObjectName: "ProjectState", CanList: true}, map[string]string{"roles_list": "admin"}),
+		setupRoles(ObjectDefinition{ObjectName: "Translation", CanList: true}, map[string]string{}),
+	}
+	tests := []struct {
+		name  string
+		role  string
+		items int
+	}{
+		{"Test 1", "", 3},
+		{"Test 2", "admin", 3},
+		{"Test 3", "manager", 2},
+		{"Test 4", "guest", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			menu := buildMenu(objects, nil, tt.role)
+			if len(menu) != 1 || len(menu[0].Items) != tt.items {
+				t.Errorf("buildMenu() %v = %v, want %v items", tt.role, menu, tt.items)
+			}
+		})
+	}
+}
+
+func Test_getMenuOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		order string
+		want  int
+	}{
+		{"Test 1", "", 0},
+		{"Test 2", " 3 ", 3},
+		{"Test 3", "first", 0},
+		{"Test 4", "-1", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getMenuOrder(map[string]string{"menuorder": tt.order}); got != tt.want {
+				t.Errorf("getMenuOrder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -85,6 +85,63 @@ type ObjectDefinition struct {
 	HasCrossval            bool
 	IsFilteredLookup       bool
 	HasPostPutAction       bool
+	HasComputed            bool
+	ComputedImports        []string
+	Tabs                   []fieldTab
+	Sections               []fieldSection
+	HasTabs                bool
+	HasSections            bool
+	DaoImports             []string
+	HasListQuery           bool
+	HasSortable            bool
+	HasSearchable          bool
+	HasFilterable          bool
+	DefaultSort            string
+	QuerySearchWc          string
+	QuerySortWc            string
+	QueryDirectionWc       string
+	HasPagination          bool
+	PageSize               int
+	SQLDialect             string
+	TemplatePager          string
+	TemplateImportResult   string
+	HasMoney               bool
+	HasDerived             bool
+	BaseCCY                string
+	HasTemporal            bool
+	HasFormatted           bool
+	Timezone               string
+	TimeLayouts            []string
+	Children               []childRelation
+	HasChildren            bool
+	Junctions              []junctionRelation
+	HasJunctions           bool
+	SourceHash             string
+	MenuSection            string
+	MenuOrder              int
+	Permissions            []actionPermission
+	Roles                  []string
+	HasRoles               bool
+	HasFieldSecurity       bool
+	HasGraphQL             bool
+	HasProto               bool
+	HasRequired            bool
+	HasPatterns            bool
+	HasRanges              bool
+	HasLengths             bool
+	HasStorage             bool
+	HasTests               bool
+	HasFieldChecks         bool
+	HasMemoryStore         bool
+	HasSeed                bool
+	SeedCount              int
+	HasImportTime          bool
+	ExportFormats          []exportFormat
+	ExportsCSV             bool
+	Translations           []translation
+	PageText               map[string]string
+	ValidationCases        []validationCase
+	ImportCase             validationCase
 }
 
 type FieldProperties struct {
@@ -125,6 +182,72 @@ type FieldProperties struct {
 	NumericStep              string
 	IsFilteredLookup         bool
 	IsCheckedHTML            string
+	IsComputed               bool
+	Expression               string
+	ComputedGo               string
+	DisplayOrder             int
+	Section                  string
+	Tab                      string
+	InList                   bool
+	IsListExcluded           bool
+	IsSortable               bool
+	IsSearchable             bool
+	IsFilterable             bool
+	SortDirection            string
+	ColumnWidth              string
+	SortHREF                 string
+	SortIcon                 string
+	FilterValue              string
+	FetchType                string
+	IsMoney                  bool
+	Currency                 string
+	CurrencyField            string
+	CurrencyLabel            string
+	Precision                string
+	IsTemporal               bool
+	InputLayout              string
+	DisplayLayout            string
+	StoreLayout              string
+	TimeDefault              string
+	HiddenFrom               []string
+	ReadOnlyFor              []string
+	IsSecured                bool
+	HiddenFromGo             string
+	ProtectedGo              string
+	SecureStart              string
+	SecureEnd                string
+	ReadOnlyRole             string
+	Min                      string
+	Max                      string
+	IsRequired               bool
+	IsNumeric                bool
+	Pattern                  string
+	SampleValue              string
+	IsRoundTrip              bool
+	IsImportable             bool
+	LabelText                string
+	TextKey                  string
+	Label                    string
+	Placeholder              string
+	Help                     string
+	Description              string
+	PlaceholderText          string
+	HelpText                 string
+}
+
+// fieldTab is a tab on the edit/new/view pages, holding one or more sections
+type fieldTab struct {
+	Name     string
+	ID       string
+	Sections []fieldSection
+}
+
+// fieldSection is a group of fields rendered as a panel (fieldset) on the edit/new/view pages
+type fieldSection struct {
+	Name   string
+	ID     string
+	Tab    string
+	Fields []FieldProperties
 }
 
 type messages struct {
@@ -152,6 +275,7 @@ const (
 	fetchField    = "Fetch"
 	defaultField  = "Default"
 	helperField   = "Helper"
+	computedField = "Computed"
 
 	enri_Type         = 0
 	enri_Field        = 1
@@ -169,6 +293,27 @@ const (
 	enri_Min          = 13
 	enri_Max          = 14
 	enri_Filter       = 15
+	enri_Expression   = 16
+	enri_Order        = 17
+	enri_Section      = 18
+	enri_Tab          = 19
+	enri_InList       = 20
+	enri_Sortable     = 21
+	enri_Searchable   = 22
+	enri_Filterable   = 23
+	enri_SortDefault  = 24
+	enri_Width        = 25
+	enri_Currency     = 26
+	enri_Precision    = 27
+	enri_ChildColumns = 28
+	enri_Table        = 29
+	enri_HiddenFrom   = 30
+	enri_ReadOnlyFor  = 31
+	enri_Label        = 32
+	enri_Placeholder  = 33
+	enri_Help         = 34
+	enri_Description  = 35
+	enri_Columns      = 36
 
 	html_disabled  = "readonly=\"true\""
 	html_hidden    = "hidden"
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	core "github.com/mt1976/mwt-goToolkit/core"
+	"github.com/mt1976/mwt-goToolkit/logs"
+)
+
+const (
+	moneyType             = "Money"
+	moneyPrecisionDefault = "2"
+)
+
+// currencySuffixes are used to find the currency column that goes with an amount, e.g. Budget & BudgetCCY
+var currencySuffixes = []string{"CCY", "Currency"}
+
+// moneyOverrides applies the Currency & Precision columns of an enrichment definition to a field.
+// Either column makes the field a Money field.
+func moneyOverrides(record []string, field FieldProperties) FieldProperties {
+	if record[enri_Currency] != "" {
+		field.Type = moneyType
+		field.Currency = strings.TrimSpace(record[enri_Currency])
+	}
+	if record[enri_Precision] != "" {
+		precision, err := strconv.Atoi(strings.TrimSpace(record[enri_Precision]))
+		if err != nil || precision < 0 {
+			logs.Warning("Invalid Precision " + record[enri_Precision] + " for " + field.FieldName)
+		} else {
+			field.Type = moneyType
+			field.Precision = strconv.Itoa(precision)
+		}
+	}
+	return field
+}
+
+// hasCurrencyColumn returns true if a column has a currency column alongside it
+func hasCurrencyColumn(name string, columns []string) bool {
+	return findCurrencyColumn(name, columns) != ""
+}
+
+func findCurrencyColumn(name string, columns []string) string {
+	for _, suffix := range currencySuffixes {
+		for _, c := range columns {
+			if strings.EqualFold(c, name+suffix) {
+				return c
+			}
+		}
+	}
+	return ""
+}
+
+// setupMoneyFields prepares the Money fields. Amounts are held as decimal text and never converted to a float,
+// they are shown in the currency of their currency field, or the base currency (baseccy) if there is none.
+func setupMoneyFields(e ObjectDefinition) ObjectDefinition {
+	var names []string
+	for _, f := range e.FieldsList {
+		names = append(names, f.FieldName)
+	}
+	e.BaseCCY = core.Properties["baseccy"]
+	for i, f := range e.FieldsList {
+		f.FetchType = f.Type
+		if f.Type != moneyType {
+			e.FieldsList[i] = f
+			continue
+		}
+		f.IsMoney = true
+		f.FetchType = "String"
+		if f.Precision == "" {
+			f.Precision = moneyPrecisionDefault
+		}
+		if f.Currency == "" {
+			f.CurrencyField = findCurrencyColumn(f.FieldName, names)
+		} else {
+			// The Currency column can name a field, or give a fixed currency code
+			for _, n := range names {
+				if strings.EqualFold(n, f.Currency) {
+					f.CurrencyField = n
+					f.Currency = ""
+					break
+				}
+			}
+		}
+		if f.CurrencyField == "" && f.Currency == "" {
+			f.Currency = e.BaseCCY
+		}
+		if f.CurrencyField != "" {
+			f.CurrencyLabel = wrapVariable(f.CurrencyField)
+		} else {
+			f.CurrencyLabel = f.Currency
+		}
+		f.FieldType = "number"
+		f.NumericStep = moneyStep(f.Precision)
+		f.Formatted = wrapVariable(f.FieldName + "_fmt")
+		f.TemplateField = f.Formatted
+		e.HasMoney = true
+		e.FieldsList[i] = f
+	}
+	if e.HasMoney && e.BaseCCY == "" {
+		logs.Warning("No baseccy in " + core.APPCONFIG + ", money fields without a currency will have no symbol")
+	}
+	e.HasDerived = e.HasComputed || e.HasMoney
+	return e
+}
+
+// moneyStep returns the HTML input step for a number of decimal places, e.g. 2 gives 0.01
+func moneyStep(precision string) string {
+	dps, _ := strconv.Atoi(precision)
+	if dps < 1 {
+		return "1"
+	}
+	return "0." + strings.Repeat("0", dps-1) + "1"
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_moneyStep(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"Test 1", "2", "0.01"},
+		{"Test 2", "4", "0.0001"},
+		{"Test 3", "0", "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := moneyStep(tt.in); got != tt.want {
+				t.Errorf("moneyStep() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_setupMoneyFields(t *testing.T) {
+	e := ObjectDefinition{ObjectName: "Test", FieldsList: []FieldProperties{
+		{FieldName: "Amount", Type: moneyType},
+		{FieldName: "AmountCCY", Type: "String"},
+		{FieldName: "Fee", Type: moneyType, Currency: "USD", Precision: "4"},
+		{FieldName: "Charge", Type: moneyType, Currency: "amountccy"},
+		{FieldName: "Rate", Type: "Float"},
+	}}
+	e = setupMoneyFields(e)
+
+	if !e.HasMoney || !e.HasDerived {
+		t.Errorf("setupMoneyFields() HasMoney = %v, HasDerived = %v, want true", e.HasMoney, e.HasDerived)
+	}
+	tests := []struct {
+		name          string
+		isMoney       bool
+		fetchType     string
+		currency      string
+		currencyField string
+		precision     string
+	}{
+		{"Test 1", true, "String", "", "AmountCCY", "2"},
+		{"Test 2", false, "String", "", "", ""},
+		{"Test 3", true, "String", "USD", "", "4"},
+		{"Test 4", true, "String", "", "AmountCCY", "2"},
+		{"Test 5", false, "Float", "", "", ""},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := e.FieldsList[i]
+			if got.IsMoney != tt.isMoney || got.FetchType != tt.fetchType || got.Currency != tt.currency || got.CurrencyField != tt.currencyField || got.Precision != tt.precision {
+				t.Errorf("setupMoneyFields() %v = %v %v %v %v %v, want %v %v %v %v %v", got.FieldName, got.IsMoney, got.FetchType, got.Currency, got.CurrencyField, got.Precision, tt.isMoney, tt.fetchType, tt.currency, tt.currencyField, tt.precision)
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	core "github.com/mt1976/mwt-goToolkit/core"
+	"github.com/mt1976/mwt-goToolkit/logs"
+)
+
+const (
+	openAPIVersion = "3.0.3"
+	openAPIFolder  = "design/openapi"
+)
+
+// openAPIFormats are the formats of the field types, every field is held as a string so the format describes its content
+var openAPIFormats = map[string]string{
+	"Int":       "int64",
+	"Float":     "decimal",
+	moneyType:   "decimal",
+	"Date":      "date",
+	"Time":      "time",
+	"DateTime":  "date-time",
+	"Timestamp": "date-time",
+}
+
+// fieldEnums are the values allowed for the field types that have a fixed set of values
+var fieldEnums = map[string][]string{
+	"Bool": {"true", "false", "True", "False"},
+}
+
+// openAPIErrors are the shared responses of the error status codes
+var openAPIErrors = map[string]string{
+	"403": "Forbidden",
+	"404": "NotFound",
+	"405": "MethodNotAllowed",
+}
+
+// openAPIDocument is an OpenAPI 3 document, it is written as JSON
+type openAPIDocument struct {
+	OpenAPI    string                 `json:"openapi"`
+	Info       openAPIInfo            `json:"info"`
+	Paths      map[string]openAPIPath `json:"paths"`
+	Components openAPIComponents      `json:"components"`
+	Tags       []openAPITag           `json:"tags,omitempty"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type openAPITag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type openAPIPath struct {
+	Get    *openAPIOperation `json:"get,omitempty"`
+	Post   *openAPIOperation `json:"post,omitempty"`
+	Put    *openAPIOperation `json:"put,omitempty"`
+	Delete *openAPIOperation `json:"delete,omitempty"`
+}
+
+type openAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Summary     string                     `json:"summary"`
+	Tags        []string                   `json:"tags"`
+	Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Name        string        `json:"name"`
+	In          string        `json:"in"`
+	Description string        `json:"description,omitempty"`
+	Required    bool          `json:"required"`
+	Schema      openAPISchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Ref         string                      `json:"$ref,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema openAPISchema `json:"schema"`
+}
+
+type openAPIComponents struct {
+	Schemas   map[string]openAPISchema   `json:"schemas"`
+	Responses map[string]openAPIResponse `json:"responses"`
+}
+
+// openAPISchema is the subset of a schema object used to describe the objects
+type openAPISchema struct {
+	Ref         string                   `json:"$ref,omitempty"`
+	Type        string                   `json:"type,omitempty"`
+	Format      string                   `json:"format,omitempty"`
+	Description string                   `json:"description,omitempty"`
+	Enum        []string                 `json:"enum,omitempty"`
+	Default     string                   `json:"default,omitempty"`
+	ReadOnly    bool                     `json:"readOnly,omitempty"`
+	Required    []string                 `json:"required,omitempty"`
+	Properties  map[string]openAPISchema `json:"properties,omitempty"`
+	Items       *openAPISchema           `json:"items,omitempty"`
+	OneOf       []openAPISchema          `json:"oneOf,omitempty"`
+}
+
+// newOpenAPIDocument returns an empty document, with the schemas & responses shared by every object
+func newOpenAPIDocument(title string, version string) openAPIDocument {
+	text := openAPISchema{Type: "string"}
+	return openAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info:    openAPIInfo{Title: title, Version: version},
+		Paths:   make(map[string]openAPIPath),
+		Components: openAPIComponents{
+			Schemas: map[string]openAPISchema{
+				"ContentList": {
+					Type:        "object",
+					Description: "The records available, each with the query that fetches it",
+					Properties: map[string]openAPISchema{
+						"Count": {Type: "integer"},
+						"Key":   {Type: "string", Description: "The query parameter that identifies a record"},
+						"Items": {Type: "array", Items: &openAPISchema{Ref: "#/components/schemas/ContentListItem"}},
+					},
+				},
+				"ContentListItem": {
+					Type: "object",
+					Properties: map[string]openAPISchema{
+						"ID":    {Type: "string"},
+						"Query": {Type: "string"},
+					},
+				},
+			},
+			Responses: map[string]openAPIResponse{
+				"NotFound":         {Description: "The record was not found, or could not be stored", Content: map[string]openAPIMediaType{"text/plain": {Schema: text}}},
+				"Forbidden":        {Description: "The user's role may not use the API", Content: map[string]openAPIMediaType{"text/plain": {Schema: text}}},
+				"MethodNotAllowed": {Description: "The action is not available", Content: map[string]openAPIMediaType{"text/plain": {Schema: text}}},
+			},
+		},
+	}
+}
+
+// addOpenAPIObject adds the endpoint & schema of an object to a document
+func addOpenAPIObject(doc openAPIDocument, e ObjectDefinition) openAPIDocument {
+	ref := openAPISchema{Ref: "#/components/schemas/" + e.ObjectName}
+	body := map[string]openAPIMediaType{"application/json": {Schema: ref}}
+	tags := []string{e.ObjectName}
+	id := openAPIParameter{Name: e.QueryString, In: "query", Description: "The " + e.QueryField + " of the " + e.FriendlyName, Schema: openAPISchema{Type: "string"}}
+
+	// withErrors adds the error responses, a role check can refuse any request
+	withErrors := func(responses map[string]openAPIResponse, codes ...string) map[string]openAPIResponse {
+		if e.HasRoles {
+			codes = append(codes, "403")
+		}
+		for _, c := range codes {
+			responses[c] = openAPIResponse{Ref: "#/components/responses/" + openAPIErrors[c]}
+		}
+		return responses
+	}
+
+	path := openAPIPath{}
+	path.Get = &openAPIOperation{
+		OperationID: e.ObjectName + "_Get",
+		Summary:     "Get a " + e.FriendlyName + ", or list every " + e.FriendlyName + " when no " + e.QueryString + " is given",
+		Tags:        tags,
+		Parameters:  []openAPIParameter{id},
+		Responses: withErrors(map[string]openAPIResponse{
+			"200": {Description: "The " + e.FriendlyName + ", or the list", Content: map[string]openAPIMediaType{"application/json": {Schema: openAPISchema{OneOf: []openAPISchema{ref, {Ref: "#/components/schemas/ContentList"}}}}}},
+		}, "404"),
+	}
+	store := func(method string) *openAPIOperation {
+		op := &openAPIOperation{
+			OperationID: e.ObjectName + "_" + method,
+			Summary:     "Store a " + e.FriendlyName,
+			Tags:        tags,
+			RequestBody: &openAPIRequestBody{Required: true, Content: body},
+			Responses:   withErrors(map[string]openAPIResponse{"200": {Description: "The " + e.FriendlyName + " was stored"}}, "404"),
+		}
+		if !e.CanSave {
+			op.Summary = "Storing a " + e.FriendlyName + " is not available"
+			op.Responses = withErrors(map[string]openAPIResponse{}, "405")
+		}
+		return op
+	}
+	path.Post = store("Post")
+	path.Put = store("Put")
+	id.Required = true
+	path.Delete = &openAPIOperation{
+		OperationID: e.ObjectName + "_Delete",
+		Summary:     "Delete a " + e.FriendlyName,
+		Tags:        tags,
+		Parameters:  []openAPIParameter{id},
+		Responses:   withErrors(map[string]openAPIResponse{"200": {Description: "The " + e.FriendlyName + " was deleted"}}),
+	}
+	if !e.CanDelete {
+		path.Delete.Summary = "Deleting a " + e.FriendlyName + " is not available"
+		path.Delete.Responses = withErrors(map[string]openAPIResponse{}, "405")
+	}
+
+	doc.Paths["/API/"+e.ObjectName+"/"] = path
+	doc.Components.Schemas[e.ObjectName] = openAPIObjectSchema(e)
+	doc.Tags = append(doc.Tags, openAPITag{Name: e.ObjectName, Description: e.FriendlyName})
+	return doc
+}
+
+// openAPIObjectSchema describes the fields of an object, every value is a string
+func openAPIObjectSchema(e ObjectDefinition) openAPISchema {
+	s := openAPISchema{Type: "object", Description: e.FriendlyName, Properties: make(map[string]openAPISchema)}
+	for _, f := range e.FieldsList {
+		p := openAPISchema{
+			Type:     "string",
+			Format:   openAPIFormats[f.Type],
+			Enum:     fieldEnums[f.Type],
+			ReadOnly: f.IsComputed || f.IsAudit,
+		}
+		if f.Default != "" && !f.IsTemporal {
+			p.Default = f.Default
+		}
+		var notes []string
+		if f.IsLookup || f.IsListLookup {
+			notes = append(notes, "Lookup of "+f.LookupObject)
+		}
+		if f.IsComputed {
+			notes = append(notes, "Computed : "+f.Expression)
+		}
+		if f.IsMoney && f.CurrencyField != "" {
+			notes = append(notes, "Currency in "+f.CurrencyField)
+		} else if f.IsMoney {
+			notes = append(notes, "Currency "+f.Currency)
+		}
+		if len(f.HiddenFrom) > 0 {
+			notes = append(notes, "Omitted for the roles "+strings.Join(f.HiddenFrom, ", "))
+		}
+		p.Description = strings.Join(notes, ". ")
+		s.Properties[f.FieldName] = p
+		if f.Formatted != "" {
+			s.Properties[f.FieldName+"_fmt"] = openAPISchema{Type: "string", ReadOnly: true, Description: "The formatted " + f.FieldName}
+		}
+		// The key, audit & computed fields are filled in by the store, so only those the checks require are required
+		if f.IsRequired {
+			s.Required = append(s.Required, f.FieldName)
+		}
+	}
+	return s
+}
+
+// generateOpenAPIArtifact writes the OpenAPI document of an object
+func generateOpenAPIArtifact(e ObjectDefinition) ObjectDefinition {
+	doc := addOpenAPIObject(newOpenAPIDocument(e.FriendlyName+" API", e.Version), e)
+	doc.Info.Description = "The API of the " + e.FriendlyName + " object of " + strings.TrimSuffix(e.ProjectRepo, "/")
+	dest := "/" + openAPIFolder + "/" + e.ObjectCamelCase + ".json"
+	name := writeDesignJSON(dest, doc)
+	if name == "" {
+		return e
+	}
+	return logArtifact("openapi", dest, e, "design", name)
+}
+
+// generateProjectOpenAPI writes a single OpenAPI document covering every object with an API
+func generateProjectOpenAPI(p projectDefinition) {
+	doc := newOpenAPIDocument(strings.TrimSuffix(p.ProjectRepo, "/")+" API", p.Version)
+	doc.Info.Description = "The API of every object of " + strings.TrimSuffix(p.ProjectRepo, "/")
+	for _, o := range p.Objects {
+		if o.CanAPI {
+			doc = addOpenAPIObject(doc, o)
+		}
+	}
+	if len(doc.Paths) == 0 {
+		logs.Skipping("OpenAPI, no object has an API")
+		return
+	}
+	writeDesignJSON("/"+openAPIFolder+"/openapi.json", doc)
+}
+
+// writeDesignJSON writes a document as JSON below the output folder, returning the name of the file written
+func writeDesignJSON(dest string, doc interface{}) string {
+	content, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		logs.Error("JSON : ", err)
+		return ""
+	}
+	name := data_out() + dest
+	if core.Properties["deliverto"] == "" {
+		name = name + "_tmp"
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0700); err != nil {
+		logs.Error("Create folder : ", err)
+		return ""
+	}
+	if err := os.WriteFile(name, append(content, '\n'), 0600); err != nil {
+		logs.Error("Create file : ", err)
+		return ""
+	}
+	logs.Created(name)
+	return name
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_addOpenAPIObject(t *testing.T) {
+	e := ObjectDefinition{ObjectName: "Project", FriendlyName: "Project", QueryString: "PR", QueryFieldID: "ProjectID", CanSave: true, HasRoles: true, FieldsList: []FieldProperties{
+		{FieldName: "ProjectID", Type: "String", IsMandatory: true},
+		{FieldName: "Name", Type: "String", IsMandatory: true},
+		{FieldName: "StartDate", Type: "Date", Formatted: "x", IsTemporal: true, Default: "today"},
+		{FieldName: "Active", Type: "Bool", Default: "True"},
+		{FieldName: "Days", Type: "Int", IsComputed: true},
+	}}
+	e = setupFieldChecks(e)
+	doc := addOpenAPIObject(newOpenAPIDocument("Test", "1"), e)
+
+	path, ok := doc.Paths["/API/Project/"]
+	if !ok {
+		t.Fatalf("addOpenAPIObject() paths = %v", doc.Paths)
+	}
+	tests := []struct {
+		name     string
+		op       *openAPIOperation
+		status   string
+		response string
+	}{
+		{"Test 1", path.Get, "404", "#/components/responses/NotFound"},
+		{"Test 2", path.Post, "403", "#/components/responses/Forbidden"},
+		{"Test 3", path.Delete, "405", "#/components/responses/MethodNotAllowed"},
+		{"Test 4", path.Put, "200", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, ok := tt.op.Responses[tt.status]
+			if !ok || r.Ref != tt.response {
+				t.Errorf("addOpenAPIObject() %v %v = %v, want %v", tt.op.OperationID, tt.status, r.Ref, tt.response)
+			}
+		})
+	}
+
+	s := doc.Components.Schemas["Project"]
+	if len(s.Required) != 1 || s.Required[0] != "Name" {
+		t.Errorf("addOpenAPIObject() required = %v", s.Required)
+	}
+	if p := s.Properties["StartDate"]; p.Format != "date" || p.Default != "" {
+		t.Errorf("addOpenAPIObject() StartDate = %v", p)
+	}
+	if p := s.Properties["StartDate_fmt"]; !p.ReadOnly {
+		t.Errorf("addOpenAPIObject() StartDate_fmt = %v", p)
+	}
+	if p := s.Properties["Active"]; len(p.Enum) == 0 || p.Default != "True" {
+		t.Errorf("addOpenAPIObject() Active = %v", p)
+	}
+	if p := s.Properties["Days"]; p.Format != "int64" || !p.ReadOnly {
+		t.Errorf("addOpenAPIObject() Days = %v", p)
+	}
+}
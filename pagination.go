@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mt1976/mwt-goToolkit/logs"
+)
+
+const (
+	pageSizeDefault  = 25
+	dialectSQLServer = "sqlserver"
+)
+
+// sqlDialects are the databases a paginated list can be generated for, sqlserver uses OFFSET/FETCH, the others LIMIT/OFFSET
+var sqlDialects = []string{dialectSQLServer, "postgres", "mysql", "sqlite"}
+
+// pagerString is the pager shown below a paginated list, it keeps the search, filter & sort of the current page
+const pagerString = `{{if gt .Pager.PageCount 1}}<nav aria-label="Pages">
+                      <ul class="pagination justify-content-center mb-0">
+                        <li class="page-item{{if not .Pager.PrevPage}} disabled{{end}}"><a class="page-link" href="?page=1{{if .Pager.Query}}&{{.Pager.Query}}{{end}}" title="First"><i class="fas fa-angle-double-left"></i></a></li>
+                        <li class="page-item{{if not .Pager.PrevPage}} disabled{{end}}"><a class="page-link" href="?page={{.Pager.PrevPage}}{{if .Pager.Query}}&{{.Pager.Query}}{{end}}" title="Previous"><i class="fas fa-angle-left"></i></a></li>
+                        <li class="page-item disabled"><span class="page-link">Page {{.Pager.Page}} of {{.Pager.PageCount}} ({{.Pager.TotalItems}})</span></li>
+                        <li class="page-item{{if not .Pager.NextPage}} disabled{{end}}"><a class="page-link" href="?page={{.Pager.NextPage}}{{if .Pager.Query}}&{{.Pager.Query}}{{end}}" title="Next"><i class="fas fa-angle-right"></i></a></li>
+                        <li class="page-item{{if not .Pager.NextPage}} disabled{{end}}"><a class="page-link" href="?page={{.Pager.PageCount}}{{if .Pager.Query}}&{{.Pager.Query}}{{end}}" title="Last"><i class="fas fa-angle-double-right"></i></a></li>
+                      </ul>
+                    </nav>{{end}}`
+
+// getPageSize returns the pagesize property, or the default if it is missing or invalid
+func getPageSize(props map[string]string) int {
+	if props["pagesize"] == "" {
+		return pageSizeDefault
+	}
+	size, err := strconv.Atoi(strings.TrimSpace(props["pagesize"]))
+	if err != nil || size < 1 {
+		logs.Warning("Invalid pagesize " + props["pagesize"] + ", using " + strconv.Itoa(pageSizeDefault))
+		return pageSizeDefault
+	}
+	return size
+}
+
+// getSQLDialect returns the sqldialect property, defaulting to sqlserver
+func getSQLDialect(props map[string]string) string {
+	dialect := strings.ToLower(strings.TrimSpace(props["sqldialect"]))
+	if dialect == "" || dialect == "mssql" {
+		return dialectSQLServer
+	}
+	for _, d := range sqlDialects {
+		if dialect == d {
+			return d
+		}
+	}
+	logs.Warning("Unknown sqldialect " + props["sqldialect"] + ", use one of " + strings.Join(sqlDialects, ", "))
+	return dialectSQLServer
+}
+
+// setupPagination prepares a paginated list, which is built on the list query (see setupListView)
+func setupPagination(e ObjectDefinition) ObjectDefinition {
+	if !e.HasPagination {
+		return e
+	}
+	if e.HasFetchAdaptor {
+		logs.Warning(e.ObjectName + " uses a fetch adaptor, it cannot be paginated")
+		e.HasPagination = false
+		return e
+	}
+	e.TemplatePager = pagerString
+	return e
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_getSQLDialect(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"Test 1", "", dialectSQLServer},
+		{"Test 2", "MSSQL", dialectSQLServer},
+		{"Test 3", "Postgres", "postgres"},
+		{"Test 4", "oracle", dialectSQLServer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getSQLDialect(map[string]string{"sqldialect": tt.in}); got != tt.want {
+				t.Errorf("getSQLDialect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getPageSize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want int
+	}{
+		{"Test 1", "", pageSizeDefault},
+		{"Test 2", "50", 50},
+		{"Test 3", "0", pageSizeDefault},
+		{"Test 4", "many", pageSizeDefault},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getPageSize(map[string]string{"pagesize": tt.in}); got != tt.want {
+				t.Errorf("getPageSize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
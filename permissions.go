@@ -0,0 +1,118 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// roleActions are the actions that can be limited to roles, each by a roles_<action> property, e.g. roles_delete=admin.
+// The roles property applies to every action without its own, an action without roles is open to every role.
+var roleActions = []string{"List", "View", "Edit", "New", "Save", "Delete", "API", "Import", "Export"}
+
+// actionPermission lists the roles that may perform an action
+type actionPermission struct {
+	Action    string
+	Enabled   bool
+	Roles     []string
+	RolesText string
+	RolesGo   string
+	Cells     []string
+}
+
+// setupRoles reads the roles of each action, the permission matrix has a column for each role used by the object
+func setupRoles(e ObjectDefinition, props map[string]string) ObjectDefinition {
+	enabled := map[string]bool{
+		"List": e.CanList, "View": e.CanView, "Edit": e.CanEdit, "New": e.CanNew,
+		"Save": e.CanSave, "Delete": e.CanDelete, "API": e.CanAPI, "Import": e.CanImport,
+		"Export": e.CanExport,
+	}
+	defaults := splitRoles(props["roles"])
+	used := make(map[string]bool)
+	e.Permissions = nil
+	for _, action := range roleActions {
+		p := actionPermission{Action: action, Enabled: enabled[action], Roles: defaults}
+		if own, ok := props["roles_"+strings.ToLower(action)]; ok && strings.TrimSpace(own) != "" {
+			p.Roles = splitRoles(own)
+		}
+		var quoted []string
+		for _, r := range p.Roles {
+			quoted = append(quoted, strconv.Quote(r))
+			used[r] = true
+		}
+		p.RolesText = strings.Join(p.Roles, ", ")
+		p.RolesGo = "[]string{" + strings.Join(quoted, ", ") + "}"
+		e.Permissions = append(e.Permissions, p)
+	}
+
+	e.Roles = nil
+	for r := range used {
+		e.Roles = append(e.Roles, r)
+	}
+	sort.Strings(e.Roles)
+	e.HasRoles = len(e.Roles) > 0
+
+	for i, p := range e.Permissions {
+		for _, r := range e.Roles {
+			e.Permissions[i].Cells = append(e.Permissions[i].Cells, permissionCell(p, r))
+		}
+	}
+	return e
+}
+
+// permissionCell is the permission matrix entry of a role for an action
+func permissionCell(p actionPermission, role string) string {
+	switch {
+	case !p.Enabled:
+		return "-"
+	case roleAllowed(p.Roles, role):
+		return "Y"
+	}
+	return ""
+}
+
+// roleAllowed returns true if a role is one of the roles, no roles allows every role
+func roleAllowed(roles []string, role string) bool {
+	if len(roles) == 0 {
+		return true
+	}
+	for _, r := range roles {
+		if strings.EqualFold(r, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitRoles splits a list of roles separated by commas, | or spaces
+func splitRoles(in string) []string {
+	return strings.FieldsFunc(in, func(r rune) bool {
+		return r == ',' || r == '|' || r == ' '
+	})
+}
+
+// listRoles returns the roles that may list an object, and so see it on the menu
+func listRoles(e ObjectDefinition) []string {
+	for _, p := range e.Permissions {
+		if p.Action == "List" {
+			return p.Roles
+		}
+	}
+	return nil
+}
+
+// projectRoles returns every role used by the objects of the project
+func projectRoles(objects []ObjectDefinition) []string {
+	used := make(map[string]bool)
+	var roles []string
+	for _, o := range objects {
+		for _, r := range o.Roles {
+			if !used[r] {
+				used[r] = true
+				roles = append(roles, r)
+			}
+		}
+	}
+	sort.Strings(roles)
+	return roles
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_setupRoles(t *testing.T) {
+	e := ObjectDefinition{CanList: true, CanView: true, CanEdit: true, CanNew: true, CanSave: true, CanDelete: true}
+	e = setupRoles(e, map[string]string{"roles": "admin, manager", "roles_delete": "admin", "roles_view": " "})
+
+	if !e.HasRoles || len(e.Roles) != 2 || e.Roles[0] != "admin" || e.Roles[1] != "manager" {
+		t.Fatalf("setupRoles() roles = %v", e.Roles)
+	}
+	tests := []struct {
+		name    string
+		action  string
+		rolesGo string
+		cells   string
+	}{
+		{"Test 1", "List", `[]string{"admin", "manager"}`, "YY"},
+		{"Test 2", "View", `[]string{"admin", "manager"}`, "YY"},
+		{"Test 3", "Delete", `[]string{"admin"}`, "Y"},
+		{"Test 4", "API", `[]string{"admin", "manager"}`, "--"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, p := range e.Permissions {
+				if p.Action != tt.action {
+					continue
+				}
+				cells := ""
+				for _, c := range p.Cells {
+					cells = cells + c
+				}
+				if p.RolesGo != tt.rolesGo || cells != tt.cells {
+					t.Errorf("setupRoles() %v = %v %v, want %v %v", p.Action, p.RolesGo, cells, tt.rolesGo, tt.cells)
+				}
+				return
+			}
+			t.Errorf("setupRoles() has no %v permission", tt.action)
+		})
+	}
+
+	open := setupRoles(ObjectDefinition{CanList: true}, map[string]string{})
+	if open.HasRoles || open.Permissions[0].RolesGo != "[]string{}" || !roleAllowed(listRoles(open), "anyone") {
+		t.Errorf("setupRoles() without roles = %v", open.Permissions[0])
+	}
+}
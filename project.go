@@ -0,0 +1,160 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	core "github.com/mt1976/mwt-goToolkit/core"
+	"github.com/mt1976/mwt-goToolkit/logs"
+)
+
+// projectDefinition is the whole project, it is built once every object definition has been processed
+type projectDefinition struct {
+	Objects       []ObjectDefinition
+	Relationships []relationship
+	Entities      []entity
+	Menu          []menuSection
+	GraphQL       []graphQLObject
+	HasMutations  bool
+	RoleActions   []string
+	Roles         []string
+	Changes       []change
+	Unchanged     int
+	Path          string
+	ProjectRepo   string
+	Version       string
+	Date          string
+	Time          string
+	Who           string
+	Host          string
+}
+
+// newProjectDefinition builds the project from the processed object definitions
+func newProjectDefinition(objects []ObjectDefinition) projectDefinition {
+	p := projectDefinition{
+		Objects: objects,
+		Path:    getPWD(),
+		Version: genReleaseName(),
+		Time:    time.Now().Format(core.TIMEFORMATUSER),
+		Date:    time.Now().Format(core.DATEFORMATUSER),
+		Host:    getHostName(),
+		Who:     getUsername(),
+	}
+	if len(objects) > 0 {
+		p.ProjectRepo = objects[0].ProjectRepo
+	}
+	p.Relationships = buildRelationships(objects)
+	p.Entities = buildEntities(objects, p.Relationships)
+	p.Menu = buildMenu(objects, strings.Split(core.Properties["menusections"], ","), "")
+	p.RoleActions = roleActions
+	p.Roles = projectRoles(objects)
+	p.GraphQL = buildGraphQL(objects)
+	p.HasMutations = hasGraphQLMutations(p.GraphQL)
+	return p
+}
+
+// generateProjectArtifacts creates the artifacts that cover every object in the project
+func generateProjectArtifacts(objects []ObjectDefinition) {
+	if len(objects) == 0 {
+		logs.Skipping("project artifacts, no objects")
+		return
+	}
+	logs.Break()
+	logs.Header("Generating Project Artifacts")
+	logs.Break()
+
+	p := newProjectDefinition(objects)
+
+	manifestPath := data_out() + "/" + manifestFile
+	current, changes := updateManifest(loadManifest(manifestPath), objects, func(name string) bool {
+		_, err := os.Stat(getPWD() + data_in() + "/" + name + ".cfg")
+		return err == nil
+	})
+	p.Changes = changes
+	p.Unchanged = len(objects)
+	for _, c := range changes {
+		if c.Change != "Removed" {
+			p.Unchanged--
+		}
+	}
+
+	processProjectArtifact("index", nfo_template, "design/catalog", "index.md", p)
+	processProjectArtifact("relationships", nfo_template, "design/catalog", "relationships.md", p)
+	processProjectArtifact("relationships", ".mmd_template", "design/catalog", "relationships.mmd", p)
+	processProjectArtifact("relationships", ".dot_template", "design/catalog", "relationships.dot", p)
+	generateMenuArtifacts(p)
+	generateProjectOpenAPI(p)
+	generateGraphQLArtifacts(p)
+	if hasArtifact(objects, "typescript") {
+		// The TypeScript objects share the client
+		name := "client.ts"
+		if core.Properties["deliverto"] == "" {
+			name = name + "_tmp"
+		}
+		processProjectArtifact("client", ".ts_template", "typescript", name, p)
+	}
+
+	generateProtoArtifacts(p, current)
+	generateSeedArtifacts(p)
+	if hasArtifact(objects, "memory") {
+		name := "memory.go"
+		if core.Properties["deliverto"] == "" {
+			name = name + "_tmp"
+		}
+		processProjectArtifact("memories", go_template, "dao", name, p)
+	}
+	if hasArtifact(objects, "import") || exportsSpreadsheet(objects) {
+		// The imports & exports share the reader & writer of CSV & Excel files
+		name := "spreadsheet.go"
+		if core.Properties["deliverto"] == "" {
+			name = name + "_tmp"
+		}
+		processProjectArtifact("spreadsheet", go_template, "dao", name, p)
+	}
+
+	current.Version, current.Date, current.Time = p.Version, p.Date, p.Time
+	saveManifest(manifestPath, current)
+}
+
+// hasArtifact returns true if any object has generated the artifact
+func hasArtifact(objects []ObjectDefinition, name string) bool {
+	for _, o := range objects {
+		for _, a := range o.Artifacts {
+			if a.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// processProjectArtifact executes a project template, writing the named file to the destination folder.
+// Every template sharing the name is loaded, so one can include another, e.g. the Markdown includes the Mermaid diagram.
+// The data is usually the project, a template can instead be given one of its parts (e.g. an object).
+func processProjectArtifact(w string, in_extn string, destFolder string, name string, data interface{}) {
+	t, err := template.ParseGlob(getPWD() + "/templates/" + w + ".*_template")
+	if err != nil {
+		logs.Error("Load Template :", err)
+		return
+	}
+
+	fullPath := data_out() + "/" + destFolder
+	if _, err := os.Stat(fullPath); os.IsNotExist(err) {
+		logs.Created(fullPath)
+		os.MkdirAll(fullPath, 0700)
+	}
+
+	f, err := os.Create(fullPath + "/" + name)
+	if err != nil {
+		logs.Error("Create file : ", err)
+		return
+	}
+	defer f.Close()
+
+	if err := t.ExecuteTemplate(f, w+in_extn, data); err != nil {
+		logs.Error("Process Template", err)
+	}
+	logs.Created(f.Name())
+}
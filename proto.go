@@ -0,0 +1,140 @@
+package main
+
+import (
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	core "github.com/mt1976/mwt-goToolkit/core"
+)
+
+// protoObject is an object of the gRPC service definitions, its numbers come from the manifest so they never change
+type protoObject struct {
+	Name         string
+	Camel        string
+	FriendlyName string
+	QueryFieldID string
+	Package      string
+	ProjectRepo  string
+	Fields       []protoField
+	Reserved     string
+	CanList      bool
+	CanView      bool
+	CanCreate    bool
+	CanUpdate    bool
+	CanDelete    bool
+	HasRoles     bool
+	Hidden       []string
+	Protected    []string
+	Version      string
+	Date         string
+	Time         string
+	Who          string
+	Host         string
+}
+
+type protoField struct {
+	Name   string
+	Number int
+}
+
+// numberProtoFields numbers the fields of an object, a field keeps the number it was given by an earlier run.
+// New fields follow the highest number ever used, and the numbers of removed fields are reserved, never reused.
+func numberProtoFields(old manifestObject, fields []string) (map[string]int, []int) {
+	next := 1
+	for _, n := range old.Proto {
+		if n >= next {
+			next = n + 1
+		}
+	}
+	for _, n := range old.ProtoReserved {
+		if n >= next {
+			next = n + 1
+		}
+	}
+	numbers := make(map[string]int)
+	for _, f := range fields {
+		if n, ok := old.Proto[f]; ok {
+			numbers[f] = n
+			continue
+		}
+		numbers[f] = next
+		next++
+	}
+	reserved := append([]int{}, old.ProtoReserved...)
+	for f, n := range old.Proto {
+		if _, kept := numbers[f]; !kept {
+			reserved = append(reserved, n)
+		}
+	}
+	sort.Ints(reserved)
+	if len(reserved) == 0 {
+		reserved = nil
+	}
+	return numbers, reserved
+}
+
+// protoPackage is the package of the service definitions, the last part of the project repository
+func protoPackage(repo string) string {
+	name := strings.ToLower(path.Base(strings.TrimSuffix(repo, "/")))
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// buildProto describes the objects that create a service definition, numbered from the manifest
+func buildProto(p projectDefinition, m manifest) []protoObject {
+	var objects []protoObject
+	for _, o := range p.Objects {
+		if !o.HasProto {
+			continue
+		}
+		mo := m.Objects[o.ObjectName]
+		po := protoObject{
+			Name:         o.ObjectName,
+			Camel:        o.ObjectCamelCase,
+			FriendlyName: o.FriendlyName,
+			QueryFieldID: o.QueryFieldID,
+			Package:      protoPackage(o.ProjectRepo),
+			ProjectRepo:  o.ProjectRepo,
+			CanList:      o.CanList,
+			CanView:      o.CanView,
+			CanCreate:    o.CanNew && o.CanSave,
+			CanUpdate:    o.CanEdit && o.CanSave,
+			CanDelete:    o.CanDelete,
+			HasRoles:     o.HasRoles,
+			Version:      p.Version,
+			Date:         p.Date,
+			Time:         p.Time,
+			Who:          p.Who,
+			Host:         p.Host,
+		}
+		po.Hidden, po.Protected = securedFields(o)
+		for _, f := range o.FieldsList {
+			po.Fields = append(po.Fields, protoField{Name: f.FieldName, Number: mo.Proto[f.FieldName]})
+		}
+		var reserved []string
+		for _, n := range mo.ProtoReserved {
+			reserved = append(reserved, strconv.Itoa(n))
+		}
+		po.Reserved = strings.Join(reserved, ", ")
+		objects = append(objects, po)
+	}
+	return objects
+}
+
+// generateProtoArtifacts writes the service definition & the server stub of each object that creates one
+func generateProtoArtifacts(p projectDefinition, m manifest) {
+	for _, po := range buildProto(p, m) {
+		server := po.Camel + "_server.go"
+		if core.Properties["deliverto"] == "" {
+			server = server + "_tmp"
+		}
+		processProjectArtifact("proto", ".proto_template", "proto", po.Camel+".proto", po)
+		processProjectArtifact("server", go_template, "grpc", server, po)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_numberProtoFields(t *testing.T) {
+	tests := []struct {
+		name         string
+		old          manifestObject
+		fields       []string
+		wantNumbers  string
+		wantReserved string
+	}{
+		{"Test 1", manifestObject{}, []string{"ID", "Name"}, "map[ID:1 Name:2]", "[]"},
+		{"Test 2", manifestObject{Proto: map[string]int{"ID": 1, "Name": 2}}, []string{"Name", "ID"}, "map[ID:1 Name:2]", "[]"},
+		{"Test 3", manifestObject{Proto: map[string]int{"ID": 1, "Code": 2, "Name": 3}}, []string{"ID", "Name", "Colour"}, "map[Colour:4 ID:1 Name:3]", "[2]"},
+		{"Test 4", manifestObject{Proto: map[string]int{"ID": 1}, ProtoReserved: []int{5}}, []string{"ID", "Code"}, "map[Code:6 ID:1]", "[5]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			numbers, reserved := numberProtoFields(tt.old, tt.fields)
+			if got := fmt.Sprint(numbers); got != tt.wantNumbers {
+				t.Errorf("numberProtoFields() numbers = %v, want %v", got, tt.wantNumbers)
+			}
+			if got := fmt.Sprint(reserved); got != tt.wantReserved {
+				t.Errorf("numberProtoFields() reserved = %v, want %v", got, tt.wantReserved)
+			}
+		})
+	}
+}
+
+func Test_protoPackage(t *testing.T) {
+	tests := []struct {
+		name string
+		repo string
+		want string
+	}{
+		{"Test 1", "github.com/mt1976/ebEstimates/", "ebestimates"},
+		{"Test 2", "github.com/x/my-app", "my_app"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := protoPackage(tt.repo); got != tt.want {
+				t.Errorf("protoPackage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
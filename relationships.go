@@ -0,0 +1,137 @@
+package main
+
+import (
+	"sort"
+)
+
+// relationshipKind describes how a kind of relationship is drawn, in Mermaid & Graphviz DOT
+type relationshipKind struct {
+	Mermaid string
+	Dot     string
+}
+
+var relationshipKinds = map[string]relationshipKind{
+	lookupField:   {"}o--||", "style=solid"},
+	listField:     {"}o--||", "style=dashed"},
+	fetchField:    {"}o--||", "style=dotted"},
+	childField:    {"||--o{", "style=bold, arrowhead=crow"},
+	junctionField: {"}o--o{", "dir=both, arrowhead=crow, arrowtail=crow"},
+}
+
+// relationship is an edge in the project's relationship graph, from an object to the object (or list) it relates to
+type relationship struct {
+	From      string
+	FromCamel string
+	To        string
+	ToCamel   string
+	Known     bool
+	Kind      string
+	Field     string
+	Via       string
+	Mermaid   string
+	Dot       string
+}
+
+// entity is a node in the project's relationship graph, lists & objects outside the project are not Known
+type entity struct {
+	Name          string
+	Camel         string
+	FriendlyName  string
+	Known         bool
+	ReverseLookup string
+	Attributes    []entityAttribute
+}
+
+type entityAttribute struct {
+	Type    string
+	Name    string
+	Key     string
+	Comment string
+}
+
+// buildRelationships collects the lookups, list lookups, fetches, children & junctions of every object
+func buildRelationships(objects []ObjectDefinition) []relationship {
+	camel := make(map[string]string)
+	for _, o := range objects {
+		camel[o.ObjectName] = o.ObjectCamelCase
+	}
+	var rels []relationship
+	add := func(o ObjectDefinition, kind string, to string, field string, via string) {
+		if to == "" {
+			return
+		}
+		toCamel, known := camel[to]
+		rels = append(rels, relationship{
+			From:      o.ObjectName,
+			FromCamel: o.ObjectCamelCase,
+			To:        to,
+			ToCamel:   toCamel,
+			Known:     known,
+			Kind:      kind,
+			Field:     field,
+			Via:       via,
+			Mermaid:   relationshipKinds[kind].Mermaid,
+			Dot:       relationshipKinds[kind].Dot,
+		})
+	}
+	for _, o := range objects {
+		for _, f := range o.FieldsList {
+			switch {
+			case f.IsLookup:
+				add(o, lookupField, f.LookupObject, f.FieldName, f.LookupField)
+			case f.IsListLookup:
+				add(o, listField, f.LookupObject, f.FieldName, "")
+			case f.IsFetch:
+				add(o, fetchField, f.LookupObject, f.FieldName, f.LookupField)
+			}
+		}
+		for _, c := range o.Children {
+			add(o, childField, c.Object, c.Name, c.ForeignKey)
+		}
+		for _, j := range o.Junctions {
+			add(o, junctionField, j.Object, j.Name, j.Table)
+		}
+	}
+	return rels
+}
+
+// buildEntities returns the objects of the project, with their key, reverse lookup & lookup fields,
+// followed by the lists & objects they relate to that are not part of the project
+func buildEntities(objects []ObjectDefinition, rels []relationship) []entity {
+	var entities []entity
+	for _, o := range objects {
+		en := entity{Name: o.ObjectName, Camel: o.ObjectCamelCase, FriendlyName: o.FriendlyName, Known: true, ReverseLookup: o.ReverseLookup}
+		seen := make(map[string]bool)
+		addAttribute := func(a entityAttribute) {
+			if a.Name == "" || seen[a.Name] {
+				return
+			}
+			seen[a.Name] = true
+			en.Attributes = append(en.Attributes, a)
+		}
+		addAttribute(entityAttribute{Type: "String", Name: o.QueryFieldID, Key: "PK"})
+		if o.ProvidesReverseLookup {
+			addAttribute(entityAttribute{Type: "String", Name: o.ReverseLookup, Key: "UK", Comment: "reverse lookup"})
+		}
+		for _, r := range rels {
+			if r.From == o.ObjectName && (r.Kind == lookupField || r.Kind == listField || r.Kind == fetchField) {
+				addAttribute(entityAttribute{Type: "String", Name: r.Field, Key: "FK", Comment: r.To})
+			}
+		}
+		entities = append(entities, en)
+	}
+
+	var others []string
+	seen := make(map[string]bool)
+	for _, r := range rels {
+		if !r.Known && !seen[r.To] {
+			seen[r.To] = true
+			others = append(others, r.To)
+		}
+	}
+	sort.Strings(others)
+	for _, name := range others {
+		entities = append(entities, entity{Name: name})
+	}
+	return entities
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_buildRelationships(t *testing.T) {
+	objects := []ObjectDefinition{
+		{ObjectName: "Project", ObjectCamelCase: "project", QueryFieldID: "ProjectID",
+			FieldsList: []FieldProperties{
+				{FieldName: "ProjectStateID", IsLookup: true, LookupObject: "ProjectState"},
+				{FieldName: "Priority", IsListLookup: true, LookupObject: "Priorities"},
+			},
+			Children:  []childRelation{{Name: "Actions", Object: "ProjectAction", ForeignKey: "ProjectID"}},
+			Junctions: []junctionRelation{{Name: "States", Object: "ProjectState", Table: "ProjectStates"}},
+		},
+		{ObjectName: "ProjectState", ObjectCamelCase: "projectState", QueryFieldID: "ProjectStateID", ProvidesReverseLookup: true, ReverseLookup: "Code"},
+	}
+	rels := buildRelationships(objects)
+
+	tests := []struct {
+		name  string
+		kind  string
+		to    string
+		known bool
+	}{
+		{"Test 1", lookupField, "ProjectState", true},
+		{"Test 2", listField, "Priorities", false},
+		{"Test 3", childField, "ProjectAction", false},
+		{"Test 4", junctionField, "ProjectState", true},
+	}
+	if len(rels) != len(tests) {
+		t.Fatalf("buildRelationships() = %v relationships, want %v", len(rels), len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rels[i]
+			if got.Kind != tt.kind || got.To != tt.to || got.Known != tt.known || got.Mermaid == "" {
+				t.Errorf("buildRelationships() = %v %v %v, want %v %v %v", got.Kind, got.To, got.Known, tt.kind, tt.to, tt.known)
+			}
+		})
+	}
+
+	entities := buildEntities(objects, rels)
+	if len(entities) != 4 || entities[2].Name != "Priorities" || entities[3].Name != "ProjectAction" {
+		t.Fatalf("buildEntities() = %v", entities)
+	}
+	if len(entities[0].Attributes) != 3 || len(entities[1].Attributes) != 2 || entities[1].Attributes[1].Key != "UK" {
+		t.Errorf("buildEntities() attributes = %v, %v", entities[0].Attributes, entities[1].Attributes)
+	}
+}
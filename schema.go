@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mt1976/mwt-goToolkit/logs"
+)
+
+const (
+	schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+	schemaFolder  = "design/schema"
+)
+
+// schemaFormats are the standard formats of the date & time types
+var schemaFormats = map[string]string{
+	"Date":      "date",
+	"Time":      "time",
+	"DateTime":  "date-time",
+	"Timestamp": "date-time",
+}
+
+// schemaPatterns are the patterns of the numeric types, every value is held as a string
+var schemaPatterns = map[string]string{
+	"Int":     `^-?[0-9]+$`,
+	"Float":   `^-?[0-9]+(\.[0-9]+)?$`,
+	moneyType: `^-?[0-9]+(\.[0-9]+)?$`,
+}
+
+// jsonSchema is the subset of a JSON Schema (draft 2020-12) used to describe the payload of an object
+type jsonSchema struct {
+	Schema      string                `json:"$schema,omitempty"`
+	ID          string                `json:"$id,omitempty"`
+	Title       string                `json:"title,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Type        string                `json:"type"`
+	Format      string                `json:"format,omitempty"`
+	Pattern     string                `json:"pattern,omitempty"`
+	Enum        []string              `json:"enum,omitempty"`
+	Default     string                `json:"default,omitempty"`
+	MinLength   *int                  `json:"minLength,omitempty"`
+	MaxLength   *int                  `json:"maxLength,omitempty"`
+	Minimum     json.Number           `json:"minimum,omitempty"`
+	Maximum     json.Number           `json:"maximum,omitempty"`
+	ReadOnly    bool                  `json:"readOnly,omitempty"`
+	Required    []string              `json:"required,omitempty"`
+	Properties  map[string]jsonSchema `json:"properties,omitempty"`
+}
+
+// schemaOverrides applies the Min & Max columns of an enrichment definition to a field
+func schemaOverrides(record []string, field FieldProperties) FieldProperties {
+	if min := strings.TrimSpace(record[enri_Min]); min != "" {
+		field.Min = min
+	}
+	if max := strings.TrimSpace(record[enri_Max]); max != "" {
+		field.Max = max
+	}
+	return field
+}
+
+// buildSchema describes the payload of an object, every value is a string so numbers are checked by pattern.
+// Min & Max are the minimum & maximum of a numeric field, or the length of any other field.
+func buildSchema(e ObjectDefinition) jsonSchema {
+	s := jsonSchema{
+		Schema:      schemaDialect,
+		ID:          "https://" + e.ProjectRepo + "schema/" + e.ObjectCamelCase + ".schema.json",
+		Title:       e.FriendlyName,
+		Description: "The " + e.FriendlyName + " payload of " + strings.TrimSuffix(e.ProjectRepo, "/"),
+		Type:        "object",
+		Properties:  make(map[string]jsonSchema),
+	}
+	for _, f := range e.FieldsList {
+		p := jsonSchema{
+			Title:    f.Label,
+			Type:     "string",
+			Format:   schemaFormats[f.Type],
+			Pattern:  fieldPattern(f),
+			Enum:     fieldEnums[f.Type],
+			ReadOnly: f.IsNoChange || f.IsAudit || f.IsComputed,
+		}
+		if f.Default != "" && !f.IsTemporal {
+			p.Default = f.Default
+		}
+		var notes []string
+		if f.Description != "" {
+			notes = append(notes, f.Description)
+		}
+		if _, numeric := schemaPatterns[f.Type]; numeric {
+			p.Minimum = schemaNumber(e, f, f.Min)
+			p.Maximum = schemaNumber(e, f, f.Max)
+		} else {
+			p.MinLength = schemaLength(e, f, f.Min)
+			p.MaxLength = schemaLength(e, f, f.Max)
+		}
+		if f.IsLookup || f.IsListLookup {
+			notes = append(notes, "Lookup of "+f.LookupObject)
+		}
+		if f.IsComputed {
+			notes = append(notes, "Computed : "+f.Expression)
+		}
+		p.Description = strings.Join(notes, ". ")
+		s.Properties[f.FieldName] = p
+		// The key, audit & computed fields are filled in by the store, so only those the checks require are required
+		if f.IsRequired {
+			s.Required = append(s.Required, f.FieldName)
+		}
+	}
+	return s
+}
+
+// schemaLength returns the length limit of a field, an invalid limit is ignored
+func schemaLength(e ObjectDefinition, f FieldProperties, limit string) *int {
+	if limit == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(limit)
+	if err != nil || n < 0 {
+		logs.Warning(e.ObjectName + " " + f.FieldName + " has an invalid length " + limit + ", it has been ignored")
+		return nil
+	}
+	return &n
+}
+
+// schemaNumber returns the range limit of a numeric field, an invalid limit is ignored
+func schemaNumber(e ObjectDefinition, f FieldProperties, limit string) json.Number {
+	if limit == "" {
+		return ""
+	}
+	if _, err := strconv.ParseFloat(limit, 64); err != nil {
+		logs.Warning(e.ObjectName + " " + f.FieldName + " has an invalid range " + limit + ", it has been ignored")
+		return ""
+	}
+	return json.Number(limit)
+}
+
+// maskPattern converts an input mask to a pattern, 9 is a digit, a is a letter & * is either
+func maskPattern(mask string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, c := range mask {
+		switch c {
+		case '9':
+			b.WriteString("[0-9]")
+		case 'a':
+			b.WriteString("[A-Za-z]")
+		case '*':
+			b.WriteString("[A-Za-z0-9]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
+
+// generateSchemaArtifact writes the JSON Schema of an object
+func generateSchemaArtifact(e ObjectDefinition) ObjectDefinition {
+	dest := "/" + schemaFolder + "/" + e.ObjectCamelCase + ".schema.json"
+	name := writeDesignJSON(dest, buildSchema(e))
+	if name == "" {
+		return e
+	}
+	return logArtifact("schema", dest, e, "design", name)
+}
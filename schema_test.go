@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_maskPattern(t *testing.T) {
+	tests := []struct {
+		name string
+		mask string
+		want string
+	}{
+		{"Test 1", "999-999", `^[0-9][0-9][0-9]-[0-9][0-9][0-9]$`},
+		{"Test 2", "aa*.9", `^[A-Za-z][A-Za-z][A-Za-z0-9]\.[0-9]$`},
+		{"Test 3", "+(9)", `^\+\([0-9]\)$`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := maskPattern(tt.mask); got != tt.want {
+				t.Errorf("maskPattern() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_buildSchema(t *testing.T) {
+	e := ObjectDefinition{ObjectName: "Project", ObjectCamelCase: "project", ProjectRepo: "github.com/x/y/", QueryFieldID: "ProjectID", FieldsList: []FieldProperties{
+		{FieldName: "ProjectID", Type: "String", IsMandatory: true},
+		{FieldName: "Name", Type: "String", IsMandatory: true, IsRequired: true, Min: "2", Max: "60"},
+		{FieldName: "Budget", Type: "Money", Min: "0", Max: "1000.50"},
+		{FieldName: "Rate", Type: "Int", Max: "lots"},
+		{FieldName: "Code", Type: "String", FieldMask: "99", Max: "many", IsNoChange: true},
+		{FieldName: "SYSCreated", Type: "DateTime", IsAudit: true, IsTemporal: true, Default: "now"},
+	}}
+	s := buildSchema(e)
+	if s.Schema != schemaDialect || s.ID != "https://github.com/x/y/schema/project.schema.json" || len(s.Required) != 1 || s.Required[0] != "Name" {
+		t.Fatalf("buildSchema() = %v %v %v", s.Schema, s.ID, s.Required)
+	}
+	if p := s.Properties["Name"]; p.MinLength == nil || *p.MinLength != 2 || p.MaxLength == nil || *p.MaxLength != 60 {
+		t.Errorf("buildSchema() Name = %v", p)
+	}
+	if p := s.Properties["Budget"]; p.Pattern == "" || p.MinLength != nil || p.Minimum != "0" || p.Maximum != "1000.50" {
+		t.Errorf("buildSchema() Budget = %v", p)
+	}
+	if p := s.Properties["Rate"]; p.Maximum != "" || p.MaxLength != nil {
+		t.Errorf("buildSchema() Rate = %v", p)
+	}
+	if p := s.Properties["Code"]; p.Pattern != `^[0-9][0-9]$` || p.MaxLength != nil || !p.ReadOnly {
+		t.Errorf("buildSchema() Code = %v", p)
+	}
+	if p := s.Properties["SYSCreated"]; p.Format != "date-time" || p.Default != "" || !p.ReadOnly {
+		t.Errorf("buildSchema() SYSCreated = %v", p)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mt1976/mwt-goToolkit/logs"
+)
+
+// securityOverrides applies the HiddenFrom & ReadOnlyFor columns of an enrichment definition to a field,
+// each is a list of roles separated by |
+func securityOverrides(record []string, field FieldProperties) FieldProperties {
+	if roles := splitRoles(record[enri_HiddenFrom]); len(roles) > 0 {
+		field.HiddenFrom = roles
+	}
+	if roles := splitRoles(record[enri_ReadOnlyFor]); len(roles) > 0 {
+		field.ReadOnlyFor = roles
+	}
+	return field
+}
+
+// securedFields lists the fields of an object hidden from some roles, and the fields some roles may not change
+func securedFields(e ObjectDefinition) (hidden []string, protected []string) {
+	for _, f := range e.FieldsList {
+		if !f.IsSecured {
+			continue
+		}
+		if len(f.HiddenFrom) > 0 {
+			hidden = append(hidden, f.FieldName)
+		}
+		protected = append(protected, f.FieldName)
+	}
+	return hidden, protected
+}
+
+// setupFieldSecurity prepares the fields hidden from, or read only for, some roles. The pages check the user's role,
+// the fields are stripped from API responses, and a role can never change them as they are restored before a save.
+func setupFieldSecurity(e ObjectDefinition) ObjectDefinition {
+	for i, f := range e.FieldsList {
+		if len(f.HiddenFrom) == 0 && len(f.ReadOnlyFor) == 0 {
+			continue
+		}
+		f.IsSecured = true
+		f.HiddenFromGo = goStrings(f.HiddenFrom)
+		f.ProtectedGo = goStrings(uniqueStrings(append(append([]string{}, f.HiddenFrom...), f.ReadOnlyFor...)))
+		if len(f.HiddenFrom) > 0 {
+			f.SecureStart = "{{if not " + roleCondition(f.HiddenFrom) + "}}"
+			f.SecureEnd = "{{end}}"
+			if f.IsSearchable || f.IsFilterable {
+				logs.Warning(e.ObjectName + " " + f.FieldName + " is hidden from some roles, it cannot be searched or filtered")
+				f.IsSearchable = false
+				f.IsFilterable = false
+			}
+		}
+		if len(f.ReadOnlyFor) > 0 {
+			f.ReadOnlyRole = "{{if " + roleCondition(f.ReadOnlyFor) + "}}readonly=\"true\" disabled{{end}}"
+		}
+		e.HasFieldSecurity = true
+		e.FieldsList[i] = f
+	}
+	return e
+}
+
+// roleCondition returns a template condition that is true when the user has one of the roles, the page's UserRoleIn
+// ignores case as the checks of the routes do
+func roleCondition(roles []string) string {
+	var quoted []string
+	for _, r := range roles {
+		quoted = append(quoted, strconv.Quote(r))
+	}
+	return "($.UserRoleIn " + strings.Join(quoted, " ") + ")"
+}
+
+// goStrings returns a list of strings as a Go literal
+func goStrings(in []string) string {
+	var quoted []string
+	for _, s := range in {
+		quoted = append(quoted, strconv.Quote(s))
+	}
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
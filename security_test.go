@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_setupFieldSecurity(t *testing.T) {
+	e := ObjectDefinition{ObjectName: "Project", FieldsList: []FieldProperties{
+		{FieldName: "Name"},
+		{FieldName: "Budget", HiddenFrom: []string{"manager"}, IsSearchable: true},
+		{FieldName: "OriginID", ReadOnlyFor: []string{"manager", "auditor"}, IsFilterable: true},
+	}}
+	e = setupFieldSecurity(e)
+	if !e.HasFieldSecurity {
+		t.Fatalf("setupFieldSecurity() HasFieldSecurity = false")
+	}
+	tests := []struct {
+		name        string
+		field       FieldProperties
+		secured     bool
+		protectedGo string
+		secureStart string
+		readOnly    string
+		findable    bool
+	}{
+		{"Test 1", e.FieldsList[0], false, "", "", "", false},
+		{"Test 2", e.FieldsList[1], true, `[]string{"manager"}`, `{{if not ($.UserRoleIn "manager")}}`, "", false},
+		{"Test 3", e.FieldsList[2], true, `[]string{"auditor", "manager"}`, "", `{{if ($.UserRoleIn "manager" "auditor")}}readonly="true" disabled{{end}}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.field
+			if f.IsSecured != tt.secured || f.ProtectedGo != tt.protectedGo || f.SecureStart != tt.secureStart || f.ReadOnlyRole != tt.readOnly {
+				t.Errorf("setupFieldSecurity() %v = %v %v %v %v", f.FieldName, f.IsSecured, f.ProtectedGo, f.SecureStart, f.ReadOnlyRole)
+			}
+			if (f.IsSearchable || f.IsFilterable) != tt.findable {
+				t.Errorf("setupFieldSecurity() %v searchable/filterable = %v, want %v", f.FieldName, f.IsSearchable || f.IsFilterable, tt.findable)
+			}
+		})
+	}
+
+	open := setupFieldSecurity(ObjectDefinition{FieldsList: []FieldProperties{{FieldName: "Name"}}})
+	if open.HasFieldSecurity {
+		t.Errorf("setupFieldSecurity() without roles HasFieldSecurity = true")
+	}
+}
+
+func Test_securityOverrides(t *testing.T) {
+	record := make([]string, enri_Columns)
+	record[enri_HiddenFrom] = "Manager|auditor"
+	record[enri_ReadOnlyFor] = "clerk"
+	f := securityOverrides(record, FieldProperties{FieldName: "Budget"})
+	if len(f.HiddenFrom) != 2 || f.HiddenFrom[0] != "Manager" || f.HiddenFrom[1] != "auditor" || len(f.ReadOnlyFor) != 1 || f.ReadOnlyFor[0] != "clerk" {
+		t.Errorf("securityOverrides() = %v %v", f.HiddenFrom, f.ReadOnlyFor)
+	}
+}
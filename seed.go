@@ -0,0 +1,328 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	core "github.com/mt1976/mwt-goToolkit/core"
+	"github.com/mt1976/mwt-goToolkit/logs"
+)
+
+const (
+	seedFolder       = "design/seed"
+	seedCountDefault = 5
+	seedDefault      = 1
+	seedUser         = "seed"
+)
+
+// seedStart is the earliest sample date/time, every sample falls within the year that follows it
+var seedStart = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// seedWords are used for the names & descriptions of the sample records
+var seedWords = []string{"Aurora", "Borealis", "Cascade", "Delta", "Ember", "Falcon", "Granite", "Harbour", "Ivory", "Juniper", "Kestrel", "Lantern"}
+
+var seedCurrencies = []string{"GBP", "USD", "EUR", "JPY", "CHF"}
+
+// seedObject is the sample records of an object, written as SQL INSERT statements & a JSON fixture
+type seedObject struct {
+	Name         string
+	Camel        string
+	FriendlyName string
+	Table        string
+	Columns      string
+	Rows         []string
+	Records      []map[string]string
+	Seed         int64
+	ProjectRepo  string
+	Version      string
+	Date         string
+	Time         string
+	Who          string
+	Host         string
+}
+
+// getSeedCount returns the seedcount property, the number of sample records of an object
+func getSeedCount(props map[string]string) int {
+	if props["seedcount"] == "" {
+		return seedCountDefault
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(props["seedcount"]))
+	if err != nil || count < 1 {
+		logs.Warning("Invalid seedcount " + props["seedcount"] + ", using " + strconv.Itoa(seedCountDefault))
+		return seedCountDefault
+	}
+	return count
+}
+
+// getSeed returns the seed property of the project (application.cfg), the same seed always gives the same records
+func getSeed() int64 {
+	if core.Properties["seed"] == "" {
+		return seedDefault
+	}
+	seed, err := strconv.ParseInt(strings.TrimSpace(core.Properties["seed"]), 10, 64)
+	if err != nil {
+		logs.Warning("Invalid seed " + core.Properties["seed"] + ", using " + strconv.Itoa(seedDefault))
+		return seedDefault
+	}
+	return seed
+}
+
+// buildSeeds creates the sample records of the objects that create them. An object is seeded after the objects it
+// looks up, so a lookup always holds a record that exists. Each object has its own source of random values, derived
+// from the seed & its name, so adding an object never changes the records of another.
+func buildSeeds(objects []ObjectDefinition, seed int64) []seedObject {
+	seeded := make(map[string][]map[string]string)
+	pending := []ObjectDefinition{}
+	for _, o := range objects {
+		if o.HasSeed {
+			pending = append(pending, o)
+		}
+	}
+	var seeds []seedObject
+	for len(pending) > 0 {
+		next, waiting := seedReady(pending, seeded)
+		if len(next) == 0 {
+			// The remaining objects look each other up, the first is seeded without the lookups that are not ready
+			logs.Warning(pending[0].ObjectName + " and the objects it looks up look each other up, some lookups are left empty")
+			next, waiting = pending[:1], pending[1:]
+		}
+		for _, o := range next {
+			s := seedRecords(o, seed, seeded)
+			seeded[o.ObjectName] = s.Records
+			seeds = append(seeds, s)
+		}
+		pending = waiting
+	}
+	return seeds
+}
+
+// seedReady splits the objects into those whose lookups have all been seeded, and those still waiting
+func seedReady(objects []ObjectDefinition, seeded map[string][]map[string]string) ([]ObjectDefinition, []ObjectDefinition) {
+	seeding := make(map[string]bool)
+	for _, o := range objects {
+		seeding[o.ObjectName] = true
+	}
+	var ready, waiting []ObjectDefinition
+	for _, o := range objects {
+		blocked := false
+		for _, f := range o.FieldsList {
+			if _, done := seeded[f.LookupObject]; f.IsLookup && f.LookupObject != o.ObjectName && seeding[f.LookupObject] && !done {
+				blocked = true
+			}
+		}
+		if blocked {
+			waiting = append(waiting, o)
+		} else {
+			ready = append(ready, o)
+		}
+	}
+	return ready, waiting
+}
+
+// seedRecords creates the sample records of an object
+func seedRecords(e ObjectDefinition, seed int64, seeded map[string][]map[string]string) seedObject {
+	h := fnv.New64a()
+	h.Write([]byte(e.ObjectName))
+	s := seedObject{
+		Name:         e.ObjectName,
+		Camel:        e.ObjectCamelCase,
+		FriendlyName: e.FriendlyName,
+		Table:        e.SQLTableName,
+		Seed:         seed,
+		ProjectRepo:  e.ProjectRepo,
+		Version:      e.Version,
+		Date:         e.Date,
+		Time:         e.Time,
+		Who:          e.Who,
+		Host:         e.Host,
+	}
+	rng := rand.New(rand.NewSource(seed ^ int64(h.Sum64())))
+	currencies := make(map[string]bool)
+	var columns []string
+	for _, f := range e.FieldsList {
+		if f.CurrencyField != "" {
+			currencies[f.CurrencyField] = true
+		}
+		if !f.IsExtra {
+			columns = append(columns, f.FieldSQL)
+		}
+	}
+	s.Columns = strings.Join(columns, ", ")
+	warned := make(map[string]bool)
+	for i := 0; i < e.SeedCount; i++ {
+		record := make(map[string]string)
+		var values []string
+		for _, f := range e.FieldsList {
+			var value string
+			switch {
+			case f.IsLookup && f.LookupObject != "":
+				targets := seeded[f.LookupObject]
+				if len(targets) == 0 {
+					if f.IsMandatory && !warned[f.FieldName] {
+						logs.Warning(e.ObjectName + " " + f.FieldName + " looks up " + f.LookupObject + ", which has no sample records")
+						warned[f.FieldName] = true
+					}
+					break
+				}
+				target := targets[rng.Intn(len(targets))]
+				value = target[strings.TrimPrefix(f.LookupField, f.LookupObject+"_")]
+			case currencies[f.FieldName]:
+				value = seedCurrencies[rng.Intn(len(seedCurrencies))]
+			default:
+				value = seedValue(e, f, i, rng)
+			}
+			record[f.FieldName] = value
+			if !f.IsExtra {
+				values = append(values, "'"+strings.ReplaceAll(value, "'", "''")+"'")
+			}
+		}
+		s.Records = append(s.Records, record)
+		s.Rows = append(s.Rows, strings.Join(values, ", "))
+	}
+	return s
+}
+
+// seedValue returns the value of a field of the i'th sample record. The key is numbered, a default is always used,
+// otherwise the value suits the type, mask, range & name of the field.
+func seedValue(e ObjectDefinition, f FieldProperties, i int, rng *rand.Rand) string {
+	if f.IsComputed {
+		return ""
+	}
+	if f.IsAudit {
+		switch {
+		case strings.HasPrefix(f.FieldName, "SYSDeleted"), f.FieldName == "SYSDbVersion":
+			return ""
+		case strings.HasSuffix(f.FieldName, "By"), strings.HasSuffix(f.FieldName, "Host"):
+			return seedUser
+		}
+		return seedStart.Format(core.DATETIMEFORMATSQLSERVER)
+	}
+	if f.FieldName == e.QueryFieldID && f.FieldMask == "" {
+		if _, numeric := schemaPatterns[f.Type]; numeric {
+			return strconv.Itoa(i + 1)
+		}
+		return seedLength(f, fmt.Sprintf("%s-%03d", strings.ToUpper(e.ObjectName), i+1))
+	}
+	if f.Default != "" && !f.IsTemporal {
+		return f.Default
+	}
+	if tt, ok := temporalTypes[f.Type]; ok {
+		t := seedStart.AddDate(0, 0, rng.Intn(365))
+		if f.Type != "Date" {
+			t = t.Add(time.Duration(rng.Intn(24*60)) * time.Minute)
+		}
+		return t.Format(tt.Store)
+	}
+	if enum := fieldEnums[f.Type]; len(enum) > 0 {
+		return enum[rng.Intn(2)]
+	}
+	if _, numeric := schemaPatterns[f.Type]; numeric {
+		dps := 0
+		if f.Type != "Int" {
+			dps = 2
+		}
+		if p, err := strconv.Atoi(f.Precision); err == nil && f.IsMoney {
+			dps = p
+		}
+		return seedNumber(f.Min, f.Max, dps, rng)
+	}
+	if f.FieldMask != "" {
+		return strings.Map(func(r rune) rune {
+			switch r {
+			case '9':
+				return rune('0' + rng.Intn(10))
+			case 'a', '*':
+				return rune('A' + rng.Intn(26))
+			}
+			return r
+		}, f.FieldMask)
+	}
+	word := seedWords[i%len(seedWords)]
+	if i >= len(seedWords) {
+		word = word + " " + strconv.Itoa(i/len(seedWords)+1)
+	}
+	name := strings.ToLower(f.FieldName)
+	var value string
+	switch {
+	case strings.Contains(name, "email"):
+		value = strings.ToLower(strings.ReplaceAll(word, " ", ".")) + "@example.com"
+	case strings.Contains(name, "phone"):
+		value = fmt.Sprintf("+44 20 7946 %04d", rng.Intn(10000))
+	case strings.Contains(name, "url"), strings.Contains(name, "link"), strings.Contains(name, "website"):
+		value = "https://example.com/" + e.ObjectCamelCase + "/" + strconv.Itoa(i+1)
+	case strings.HasSuffix(name, "ccy"), strings.Contains(name, "currency"):
+		value = seedCurrencies[rng.Intn(len(seedCurrencies))]
+	case strings.Contains(name, "code"):
+		value = strings.ToUpper(word[:3]) + strconv.Itoa(i+1)
+	case strings.Contains(name, "description"), strings.Contains(name, "notes"), strings.Contains(name, "comment"):
+		value = "The " + word + " " + strings.ToLower(e.FriendlyName) + ", a sample record"
+	case strings.Contains(name, "name"), strings.Contains(name, "title"):
+		value = word
+	default:
+		value = f.FieldName + " " + strconv.Itoa(i+1)
+	}
+	return seedLength(f, value)
+}
+
+// seedLength pads or cuts a value to the length limits of its field
+func seedLength(f FieldProperties, value string) string {
+	if min, err := strconv.Atoi(f.Min); err == nil && len(value) < min {
+		value = value + strings.Repeat("x", min-len(value))
+	}
+	if max, err := strconv.Atoi(f.Max); err == nil && len(value) > max {
+		value = value[:max]
+	}
+	return value
+}
+
+// seedNumber returns a random number with a number of decimal places, from min to max. Without a range the number is
+// from 0 to 100, or within 100 of the one limit given.
+func seedNumber(min string, max string, dps int, rng *rand.Rand) string {
+	lo, okLo := new(big.Rat).SetString(min)
+	hi, okHi := new(big.Rat).SetString(max)
+	switch {
+	case !okLo && !okHi:
+		lo, hi = big.NewRat(0, 1), big.NewRat(100, 1)
+	case !okHi:
+		hi = new(big.Rat).Add(lo, big.NewRat(100, 1))
+	case !okLo:
+		lo = new(big.Rat).Sub(hi, big.NewRat(100, 1))
+	}
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(dps)), nil))
+	// The range is counted in the smallest unit, e.g. pence, so every number has exactly dps decimal places
+	first := ceilRat(new(big.Rat).Mul(lo, scale))
+	last := floorRat(new(big.Rat).Mul(hi, scale))
+	if last.Cmp(first) < 0 {
+		return lo.FloatString(dps)
+	}
+	span := new(big.Int).Sub(last, first)
+	pick := new(big.Int).Add(first, new(big.Int).Rand(rng, span.Add(span, big.NewInt(1))))
+	return new(big.Rat).Quo(new(big.Rat).SetInt(pick), scale).FloatString(dps)
+}
+
+// floorRat returns the largest integer not above r, big.Int.Div rounds towards minus infinity as the denominator is positive
+func floorRat(r *big.Rat) *big.Int {
+	return new(big.Int).Div(r.Num(), r.Denom())
+}
+
+// ceilRat returns the smallest integer not below r
+func ceilRat(r *big.Rat) *big.Int {
+	return new(big.Int).Neg(floorRat(new(big.Rat).Neg(r)))
+}
+
+// generateSeedArtifacts writes the sample records of each object that creates them, as SQL & as a JSON fixture
+func generateSeedArtifacts(p projectDefinition) {
+	for _, s := range buildSeeds(p.Objects, getSeed()) {
+		name := s.Camel + ".sql"
+		if core.Properties["deliverto"] == "" {
+			name = name + "_tmp"
+		}
+		processProjectArtifact("seed", ".sql_template", seedFolder, name, s)
+		writeDesignJSON("/"+seedFolder+"/"+s.Camel+".json", s.Records)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func Test_getSeedCount(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want int
+	}{
+		{"Test 1", "", seedCountDefault},
+		{"Test 2", "12", 12},
+		{"Test 3", "0", seedCountDefault},
+		{"Test 4", "many", seedCountDefault},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getSeedCount(map[string]string{"seedcount": tt.in}); got != tt.want {
+				t.Errorf("getSeedCount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_seedNumber(t *testing.T) {
+	tests := []struct {
+		name string
+		min  string
+		max  string
+		dps  int
+		lo   string
+		hi   string
+	}{
+		{"Test 1", "", "", 0, "0", "100"},
+		{"Test 2", "5.5", "6", 2, "5.5", "6"},
+		{"Test 3", "", "-3", 0, "-103", "-3"},
+	}
+	rng := rand.New(rand.NewSource(seedDefault))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				got := seedNumber(tt.min, tt.max, tt.dps, rng)
+				value, ok := new(big.Rat).SetString(got)
+				lo, _ := new(big.Rat).SetString(tt.lo)
+				hi, _ := new(big.Rat).SetString(tt.hi)
+				if !ok || value.Cmp(lo) < 0 || value.Cmp(hi) > 0 || value.FloatString(tt.dps) != got {
+					t.Fatalf("seedNumber() = %v, want %v to %v", got, tt.lo, tt.hi)
+				}
+			}
+		})
+	}
+}
+
+func Test_buildSeeds(t *testing.T) {
+	objects := []ObjectDefinition{
+		{ObjectName: "Project", QueryFieldID: "ProjectID", HasSeed: true, SeedCount: 3, FieldsList: []FieldProperties{
+			{FieldName: "ProjectID", FieldSQL: "projectID", Type: "String"},
+			{FieldName: "StateID", FieldSQL: "stateID", Type: "String", IsLookup: true, LookupObject: "State", LookupField: "State_Code", IsMandatory: true},
+			{FieldName: "Name", FieldSQL: "name", Type: "String", Max: "4"},
+			{FieldName: "Active", FieldSQL: "active", Type: "Bool", Default: "true"},
+			{FieldName: "Sessions", Type: "Int", IsExtra: true},
+		}},
+		{ObjectName: "State", QueryFieldID: "StateID", HasSeed: true, SeedCount: 2, FieldsList: []FieldProperties{
+			{FieldName: "StateID", FieldSQL: "stateID", Type: "Int"},
+			{FieldName: "Code", FieldSQL: "code", Type: "String", FieldMask: "aa-9"},
+		}},
+		{ObjectName: "Unseeded", QueryFieldID: "UnseededID"},
+	}
+	seeds := buildSeeds(objects, seedDefault)
+	if len(seeds) != 2 || seeds[0].Name != "State" || seeds[1].Name != "Project" {
+		t.Fatalf("buildSeeds() = %v, want State then Project", seeds)
+	}
+	if !reflect.DeepEqual(seeds, buildSeeds(objects, seedDefault)) {
+		t.Errorf("buildSeeds() is not the same for the same seed")
+	}
+	if reflect.DeepEqual(seeds, buildSeeds(objects, seedDefault+1)) {
+		t.Errorf("buildSeeds() is the same for another seed")
+	}
+	codes := make(map[string]bool)
+	for i, r := range seeds[0].Records {
+		if want := []string{"1", "2"}[i]; r["StateID"] != want {
+			t.Errorf("buildSeeds() StateID = %v, want %v", r["StateID"], want)
+		}
+		codes[r["Code"]] = true
+	}
+	project := seeds[1]
+	if project.Columns != "projectID, stateID, name, active" || len(project.Rows) != 3 {
+		t.Errorf("buildSeeds() Project = %v %v", project.Columns, project.Rows)
+	}
+	for _, r := range project.Records {
+		if !codes[r["StateID"]] {
+			t.Errorf("buildSeeds() StateID = %v, not a seeded State", r["StateID"])
+		}
+		if len(r["Name"]) > 4 || r["Active"] != "true" || r["Sessions"] == "" {
+			t.Errorf("buildSeeds() Project = %v", r)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mt1976/mwt-goToolkit/logs"
+)
+
+// invalidPattern is a value no numeric type or ordinary mask accepts
+const invalidPattern = "!"
+
+// sampleTime is the date & time of every sample date/time value, formatted in the layout the field is stored in
+var sampleTime = time.Date(2024, time.January, 31, 12, 30, 0, 0, time.UTC)
+
+// validationCase is a generated test of X_ValidateFields, the field is set to the value on an otherwise valid record
+type validationCase struct {
+	Name  string
+	Field string
+	Value string
+	Valid bool
+}
+
+// fieldPattern returns the pattern a field is checked against, the pattern of its numeric type or of its mask
+func fieldPattern(f FieldProperties) string {
+	if f.IsTemporal {
+		return ""
+	}
+	if p, numeric := schemaPatterns[f.Type]; numeric {
+		return p
+	}
+	if f.FieldMask != "" {
+		return maskPattern(f.FieldMask)
+	}
+	return ""
+}
+
+// setupFieldChecks prepares the checks X_ValidateFields makes before a record is stored, the mandatory fields,
+// the patterns of the numeric & masked fields, and Min & Max as the range of a number or the length of anything else.
+// The key, audit & computed fields are never mandatory, as they are filled in when the record is stored or fetched.
+// An invalid Min or Max is ignored.
+func setupFieldChecks(e ObjectDefinition) ObjectDefinition {
+	for i, f := range e.FieldsList {
+		f.IsRequired = f.IsMandatory && f.FieldName != e.QueryFieldID && !f.IsAudit && !f.IsComputed
+		f.Pattern = fieldPattern(f)
+		_, f.IsNumeric = schemaPatterns[f.Type]
+		f.Min = checkLimit(e, f, f.Min)
+		f.Max = checkLimit(e, f, f.Max)
+		f.SampleValue = sampleValue(f)
+		f.IsRoundTrip = !f.IsTemporal && !f.IsComputed && !f.IsAudit && !f.HasCallout
+		e.HasRequired = e.HasRequired || f.IsRequired
+		e.HasPatterns = e.HasPatterns || f.Pattern != ""
+		if f.Min != "" || f.Max != "" {
+			e.HasRanges = e.HasRanges || f.IsNumeric
+			e.HasLengths = e.HasLengths || !f.IsNumeric
+		}
+		e.FieldsList[i] = f
+	}
+	// Only the objects tested or kept in memory check their fields on a save & reach the database through a storage,
+	// every other object is stored as it always has been
+	e.HasFieldChecks = e.HasTests || e.HasMemoryStore
+	e.HasStorage = e.HasFieldChecks && !e.HasFetchAdaptor && !e.HasStoreAdaptor
+	e.ValidationCases = validationCases(e)
+	return e
+}
+
+// checkLimit returns the Min or Max of a field, or nothing if it is not a number (or a length) the field can use
+func checkLimit(e ObjectDefinition, f FieldProperties, limit string) string {
+	if limit == "" || f.IsTemporal {
+		return ""
+	}
+	if _, numeric := schemaPatterns[f.Type]; numeric {
+		if _, ok := new(big.Rat).SetString(limit); !ok {
+			logs.Warning(e.ObjectName + " " + f.FieldName + " has an invalid range " + limit + ", it has been ignored")
+			return ""
+		}
+		return limit
+	}
+	if n, err := strconv.Atoi(limit); err != nil || n < 0 {
+		logs.Warning(e.ObjectName + " " + f.FieldName + " has an invalid length " + limit + ", it has been ignored")
+		return ""
+	}
+	return limit
+}
+
+// sampleValue returns a value that passes the checks of a field, used to build the records of the generated tests.
+// The audit & computed fields are left empty as they are filled in when a record is stored or fetched.
+func sampleValue(f FieldProperties) string {
+	if f.IsAudit || f.IsComputed {
+		return ""
+	}
+	if tt, ok := temporalTypes[f.Type]; ok {
+		return sampleTime.Format(tt.Store)
+	}
+	if enum := fieldEnums[f.Type]; len(enum) > 0 {
+		return enum[0]
+	}
+	if _, numeric := schemaPatterns[f.Type]; numeric {
+		sample := "1"
+		if f.Min != "" {
+			sample = f.Min
+		} else if max, ok := new(big.Rat).SetString(f.Max); ok && max.Cmp(big.NewRat(1, 1)) < 0 {
+			sample = f.Max
+		}
+		if f.IsMoney {
+			// A money field is rounded to its precision when it is validated, so the sample already is
+			dps, _ := strconv.Atoi(f.Precision)
+			value, _ := new(big.Rat).SetString(sample)
+			sample = value.FloatString(dps)
+		}
+		return sample
+	}
+	if f.FieldMask != "" {
+		return strings.Map(func(r rune) rune {
+			switch r {
+			case '9':
+				return '1'
+			case 'a', '*':
+				return 'A'
+			}
+			return r
+		}, f.FieldMask)
+	}
+	sample := "Test" + f.FieldName
+	if min, err := strconv.Atoi(f.Min); err == nil && len(sample) < min {
+		sample = sample + strings.Repeat("x", min-len(sample))
+	}
+	if max, err := strconv.Atoi(f.Max); err == nil && len(sample) > max {
+		sample = sample[:max]
+	}
+	return sample
+}
+
+// validationCases returns the generated tests of X_ValidateFields, a valid record followed by a value breaking
+// each check of each field
+func validationCases(e ObjectDefinition) []validationCase {
+	cases := []validationCase{{Name: "Valid record", Valid: true}}
+	for _, f := range e.FieldsList {
+		if f.IsRequired {
+			cases = append(cases, validationCase{Name: f.FieldName + " is mandatory", Field: f.FieldName})
+		}
+		if f.Pattern != "" && !regexp.MustCompile(f.Pattern).MatchString(invalidPattern) {
+			cases = append(cases, validationCase{Name: f.FieldName + " has an invalid format", Field: f.FieldName, Value: invalidPattern})
+		}
+		if f.IsNumeric {
+			if f.Min != "" {
+				cases = append(cases, validationCase{Name: f.FieldName + " is below " + f.Min, Field: f.FieldName, Value: offsetNumber(f.Min, -1)})
+			}
+			if f.Max != "" {
+				cases = append(cases, validationCase{Name: f.FieldName + " is above " + f.Max, Field: f.FieldName, Value: offsetNumber(f.Max, 1)})
+			}
+			continue
+		}
+		if min, err := strconv.Atoi(f.Min); err == nil && min > 1 {
+			cases = append(cases, validationCase{Name: f.FieldName + " is shorter than " + f.Min, Field: f.FieldName, Value: strings.Repeat("x", min-1)})
+		}
+		if max, err := strconv.Atoi(f.Max); err == nil {
+			cases = append(cases, validationCase{Name: f.FieldName + " is longer than " + f.Max, Field: f.FieldName, Value: strings.Repeat("x", max+1)})
+		}
+	}
+	return cases
+}
+
+// offsetNumber adds a whole number to a number, keeping the decimal places it was written with
+func offsetNumber(number string, by int64) string {
+	value, _ := new(big.Rat).SetString(number)
+	value.Add(value, big.NewRat(by, 1))
+	dps := 0
+	if dot := strings.Index(number, "."); dot >= 0 {
+		dps = len(number) - dot - 1
+	}
+	return value.FloatString(dps)
+}
+
+// generateTestArtifacts writes the tests of the dao & the datamodel of an object
+func generateTestArtifacts(configFile string, e ObjectDefinition) ObjectDefinition {
+	if !e.HasTests {
+		logs.Skipping("tests")
+		return e
+	}
+	e = processCodeArtifact("daotest", configFile, "daotest", e)
+	e = processCodeArtifact("datamodeltest", configFile, "datamodeltest", e)
+	return e
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_sampleValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		field FieldProperties
+		want  string
+	}{
+		{"Test 1", FieldProperties{FieldName: "Name", Type: "String"}, "TestName"},
+		{"Test 2", FieldProperties{FieldName: "Code", Type: "String", Max: "3"}, "Tes"},
+		{"Test 3", FieldProperties{FieldName: "ID", Type: "String", Min: "8"}, "TestIDxx"},
+		{"Test 4", FieldProperties{FieldName: "Phone", Type: "String", FieldMask: "(999) aa*"}, "(111) AAA"},
+		{"Test 5", FieldProperties{FieldName: "Budget", Type: moneyType, IsMoney: true, Precision: "2"}, "1.00"},
+		{"Test 6", FieldProperties{FieldName: "Rate", Type: "Float", Min: "5.5"}, "5.5"},
+		{"Test 7", FieldProperties{FieldName: "Offset", Type: "Int", Max: "-3"}, "-3"},
+		{"Test 8", FieldProperties{FieldName: "Due", Type: "Timestamp", IsTemporal: true}, "2024-01-31T12:30:00Z"},
+		{"Test 9", FieldProperties{FieldName: "Active", Type: "Bool"}, "true"},
+		{"Test 10", FieldProperties{FieldName: "SYSCreated", Type: "DateTime", IsAudit: true}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sampleValue(tt.field); got != tt.want {
+				t.Errorf("sampleValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_offsetNumber(t *testing.T) {
+	tests := []struct {
+		name   string
+		number string
+		by     int64
+		want   string
+	}{
+		{"Test 1", "0", -1, "-1"},
+		{"Test 2", "99", 1, "100"},
+		{"Test 3", "2.50", 1, "3.50"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := offsetNumber(tt.number, tt.by); got != tt.want {
+				t.Errorf("offsetNumber() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_setupFieldChecks(t *testing.T) {
+	e := ObjectDefinition{ObjectName: "Project", QueryFieldID: "ProjectID", HasTests: true, FieldsList: []FieldProperties{
+		{FieldName: "ProjectID", Type: "String", IsMandatory: true},
+		{FieldName: "Name", Type: "String", IsMandatory: true, Min: "2", Max: "60"},
+		{FieldName: "Budget", Type: moneyType, IsMoney: true, Precision: "2", Min: "0", Max: "big"},
+		{FieldName: "SYSCreated", Type: "DateTime", IsTemporal: true, IsAudit: true, IsMandatory: true},
+	}}
+	e = setupFieldChecks(e)
+	if !e.HasRequired || !e.HasPatterns || !e.HasRanges || !e.HasLengths || !e.HasStorage {
+		t.Fatalf("setupFieldChecks() = %v %v %v %v %v", e.HasRequired, e.HasPatterns, e.HasRanges, e.HasLengths, e.HasStorage)
+	}
+	if f := e.FieldsList[0]; f.IsRequired || f.Pattern != "" {
+		t.Errorf("setupFieldChecks() ProjectID = %v %v", f.IsRequired, f.Pattern)
+	}
+	if f := e.FieldsList[2]; !f.IsNumeric || f.Min != "0" || f.Max != "" || f.Pattern == "" {
+		t.Errorf("setupFieldChecks() Budget = %v %v %v %v", f.IsNumeric, f.Min, f.Max, f.Pattern)
+	}
+	if f := e.FieldsList[3]; f.IsRequired || f.IsRoundTrip {
+		t.Errorf("setupFieldChecks() SYSCreated = %v %v", f.IsRequired, f.IsRoundTrip)
+	}
+	want := []validationCase{
+		{Name: "Valid record", Valid: true},
+		{Name: "Name is mandatory", Field: "Name"},
+		{Name: "Name is shorter than 2", Field: "Name", Value: "x"},
+		{Name: "Name is longer than 60", Field: "Name", Value: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx"},
+		{Name: "Budget has an invalid format", Field: "Budget", Value: invalidPattern},
+		{Name: "Budget is below 0", Field: "Budget", Value: "-1"},
+	}
+	if len(e.ValidationCases) != len(want) {
+		t.Fatalf("setupFieldChecks() ValidationCases = %v, want %v", e.ValidationCases, want)
+	}
+	for i := range want {
+		if e.ValidationCases[i] != want[i] {
+			t.Errorf("setupFieldChecks() ValidationCases[%v] = %v, want %v", i, e.ValidationCases[i], want[i])
+		}
+	}
+	untested := setupFieldChecks(ObjectDefinition{ObjectName: "State", QueryFieldID: "StateID"})
+	if untested.HasFieldChecks || untested.HasStorage {
+		t.Errorf("setupFieldChecks() untested = %v %v", untested.HasFieldChecks, untested.HasStorage)
+	}
+}